@@ -0,0 +1,85 @@
+/*
+ * Copyright 2016-2024 The OSHI Project Contributors
+ * SPDX-License-Identifier: MIT
+ */
+
+// Package cpuid identifies x86 processors with the cpuid instruction, independently of the operating system.
+package cpuid
+
+import (
+	"encoding/binary"
+	"fmt"
+	"strings"
+)
+
+const (
+	leafVendor    = 0x0
+	leafSignature = 0x1
+	leafExtended  = 0x80000000
+	leafBrand     = 0x80000002
+	hypervisorBit = 1 << 31
+)
+
+type Info struct {
+	Vendor, Brand           string
+	Family, Model, Stepping int
+	// ProcessorID is the edx and eax registers of the signature leaf, as Win32_Processor.ProcessorId reports it
+	ProcessorID string
+	Hypervisor  bool
+}
+
+func registerString(regs ...uint32) string {
+	b := make([]byte, 4*len(regs))
+	for i, reg := range regs {
+		binary.LittleEndian.PutUint32(b[4*i:], reg)
+	}
+	return strings.TrimSpace(strings.Trim(string(b), "\x00"))
+}
+
+// parseSignature decodes family, model and stepping from the eax register of the signature leaf
+func parseSignature(eax uint32) (int, int, int) {
+	stepping := int(eax & 0xF)
+	model := int(eax>>4) & 0xF
+	family := int(eax>>8) & 0xF
+	extModel := int(eax>>16) & 0xF
+	extFamily := int(eax>>20) & 0xFF
+	if family == 0x6 || family == 0xF {
+		model += extModel << 4
+	}
+	if family == 0xF {
+		family += extFamily
+	}
+	return family, model, stepping
+}
+
+// processorID formats the edx and eax registers of the signature leaf, edx first
+func processorID(edx, eax uint32) string {
+	return fmt.Sprintf("%08X%08X", edx, eax)
+}
+
+// Read returns the identification of the processor the calling thread runs on.
+// It returns false on architectures without cpuid.
+func Read() (Info, bool) {
+	if !supported {
+		return Info{}, false
+	}
+	maxLeaf, ebx, ecx, edx := cpuid(leafVendor, 0)
+	info := Info{
+		Vendor: registerString(ebx, edx, ecx),
+	}
+	if maxLeaf >= leafSignature {
+		eax, _, ecx, edx := cpuid(leafSignature, 0)
+		info.Family, info.Model, info.Stepping = parseSignature(eax)
+		info.ProcessorID = processorID(edx, eax)
+		info.Hypervisor = ecx&hypervisorBit != 0
+	}
+	if maxExt, _, _, _ := cpuid(leafExtended, 0); maxExt >= leafBrand+2 {
+		regs := make([]uint32, 0, 12)
+		for leaf := uint32(leafBrand); leaf <= leafBrand+2; leaf++ {
+			eax, ebx, ecx, edx := cpuid(leaf, 0)
+			regs = append(regs, eax, ebx, ecx, edx)
+		}
+		info.Brand = registerString(regs...)
+	}
+	return info, true
+}
@@ -0,0 +1,11 @@
+/*
+ * Copyright 2016-2024 The OSHI Project Contributors
+ * SPDX-License-Identifier: MIT
+ */
+
+package cpuid
+
+const supported = true
+
+// implemented in cpuid_amd64.s
+func cpuid(eaxArg, ecxArg uint32) (eax, ebx, ecx, edx uint32)
@@ -0,0 +1,14 @@
+//go:build !amd64
+
+/*
+ * Copyright 2016-2024 The OSHI Project Contributors
+ * SPDX-License-Identifier: MIT
+ */
+
+package cpuid
+
+const supported = false
+
+func cpuid(eaxArg, ecxArg uint32) (eax, ebx, ecx, edx uint32) {
+	return 0, 0, 0, 0
+}
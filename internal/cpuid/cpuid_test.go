@@ -0,0 +1,63 @@
+/*
+ * Copyright 2016-2024 The OSHI Project Contributors
+ * SPDX-License-Identifier: MIT
+ */
+
+package cpuid
+
+import "testing"
+
+func TestParseSignature(t *testing.T) {
+	tests := []struct {
+		name                    string
+		eax                     uint32
+		family, model, stepping int
+	}{
+		// the extended model extends family 6
+		{"alder lake", 0x000906A3, 6, 0x9A, 3},
+		{"pentium pro", 0x00000619, 6, 1, 9},
+		// family 0xF adds the extended family and extends the model
+		{"zen 3", 0x00A20F12, 0x19, 0x21, 2},
+		{"pentium 4", 0x00000F29, 0xF, 2, 9},
+		// other families ignore both extended fields
+		{"pentium", 0x0FF00543, 5, 4, 3},
+		{"zero", 0, 0, 0, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			family, model, stepping := parseSignature(tt.eax)
+			if family != tt.family || model != tt.model || stepping != tt.stepping {
+				t.Errorf("parseSignature(%#08x) = %d, %d, %d, want %d, %d, %d",
+					tt.eax, family, model, stepping, tt.family, tt.model, tt.stepping)
+			}
+		})
+	}
+}
+
+func TestProcessorID(t *testing.T) {
+	tests := []struct {
+		edx, eax uint32
+		want     string
+	}{
+		{0xBFEBFBFF, 0x000906A3, "BFEBFBFF000906A3"},
+		// both registers are zero padded to eight digits
+		{0x178BFBFF, 0x00A20F12, "178BFBFF00A20F12"},
+		{0x1, 0x2, "0000000100000002"},
+	}
+	for _, tt := range tests {
+		if got := processorID(tt.edx, tt.eax); got != tt.want {
+			t.Errorf("processorID(%#x, %#x) = %s, want %s", tt.edx, tt.eax, got, tt.want)
+		}
+	}
+}
+
+func TestRegisterString(t *testing.T) {
+	// the ebx, edx and ecx registers of leaf 0 on an Intel processor
+	if got := registerString(0x756E6547, 0x49656E69, 0x6C65746E); got != "GenuineIntel" {
+		t.Errorf("vendor = %q", got)
+	}
+	// brand strings are padded with nul bytes and spaces
+	if got := registerString(0x20202020, 0x6C65746E, 0x00000000); got != "ntel" {
+		t.Errorf("brand = %q", got)
+	}
+}
@@ -0,0 +1,192 @@
+/*
+ * Copyright 2016-2024 The OSHI Project Contributors
+ * SPDX-License-Identifier: MIT
+ */
+
+// Package ids resolves vendor and device ids to names using embedded copies of the
+// usb.ids and pci.ids databases.
+package ids
+
+import (
+	"bytes"
+	"compress/gzip"
+	_ "embed"
+	"io"
+	"strings"
+	"sync"
+)
+
+var (
+	// usb.ids.gz is the usb.ids database of http://www.linux-usb.org/, version 2017.02.12 dated
+	// 2017-02-12 20:34:05, compressed with gzip -9n
+	//go:embed usb.ids.gz
+	usbIds []byte
+	// pci.ids.gz is the pci.ids database of https://pci-ids.ucw.cz/, version 2025.03.04 dated
+	// 2025-03-04 03:15:02, compressed with gzip -9n
+	//go:embed pci.ids.gz
+	pciIds []byte
+
+	usbOnce sync.Once
+	usb     database
+	pciOnce sync.Once
+	pci     database
+)
+
+type device struct {
+	name       string
+	subsystems map[string]string
+}
+
+type vendor struct {
+	name    string
+	devices map[string]device
+}
+
+type database struct {
+	vendors map[string]vendor
+	// classes are keyed by class, class and subclass, and class, subclass and programming interface
+	classes map[string]string
+}
+
+// parseIds reads the vendor, device and subsystem lines of an ids database and its class lists.
+// Entries are indented with a tab for every level.
+func parseIds(data string) database {
+	db := database{
+		vendors: make(map[string]vendor),
+		classes: make(map[string]string),
+	}
+	var currentVendor *vendor
+	var currentDevice *device
+	var class, subclass string
+	inClasses := false
+	for _, line := range strings.Split(data, "\n") {
+		if len(line) == 0 || line[0] == '#' {
+			continue
+		}
+		depth := len(line) - len(strings.TrimLeft(line, "\t"))
+		if depth == 0 {
+			// other lists such as the usb "HID" and "L" sections follow the classes
+			inClasses = strings.HasPrefix(line, "C ")
+			line = strings.TrimPrefix(line, "C ")
+			currentVendor, currentDevice = nil, nil
+		}
+		id, name, found := strings.Cut(strings.TrimSpace(line), "  ")
+		if !found {
+			continue
+		}
+		id, name = strings.ToLower(id), strings.TrimSpace(name)
+		if inClasses {
+			switch depth {
+			case 0:
+				class, subclass = id, ""
+				db.classes[class] = name
+			case 1:
+				subclass = class + id
+				db.classes[subclass] = name
+			case 2:
+				if len(subclass) != 0 {
+					db.classes[subclass+id] = name
+				}
+			}
+			continue
+		}
+		switch depth {
+		case 0:
+			// the usb lists after the classes have ids such as "R 00", which are not vendors
+			if !isVendorId(id) {
+				continue
+			}
+			v := vendor{name: name, devices: make(map[string]device)}
+			db.vendors[id] = v
+			currentVendor = &v
+		case 1:
+			if currentVendor != nil {
+				d := device{name: name, subsystems: make(map[string]string)}
+				currentVendor.devices[id] = d
+				currentDevice = &d
+			}
+		case 2:
+			if currentDevice != nil {
+				currentDevice.subsystems[id] = name
+			}
+		}
+	}
+	return db
+}
+
+// isVendorId reports whether id has the 4 hex digits of a vendor id
+func isVendorId(id string) bool {
+	if len(id) != 4 {
+		return false
+	}
+	for _, c := range id {
+		if !strings.ContainsRune("0123456789abcdef", c) {
+			return false
+		}
+	}
+	return true
+}
+
+func normalize(id string) string {
+	return strings.ToLower(strings.TrimPrefix(strings.TrimSpace(id), "0x"))
+}
+
+// parseCompressedIds decompresses and parses an embedded database, an empty one if it is corrupt
+func parseCompressedIds(compressed []byte) database {
+	r, err := gzip.NewReader(bytes.NewReader(compressed))
+	if err != nil {
+		return parseIds("")
+	}
+	data, _ := io.ReadAll(r)
+	return parseIds(string(data))
+}
+
+func usbDatabase() database {
+	usbOnce.Do(func() { usb = parseCompressedIds(usbIds) })
+	return usb
+}
+
+func pciDatabase() database {
+	pciOnce.Do(func() { pci = parseCompressedIds(pciIds) })
+	return pci
+}
+
+// UsbVendor returns the name of a usb vendor id such as "046d", empty if it is unknown.
+func UsbVendor(vendorId string) string {
+	return usbDatabase().vendors[normalize(vendorId)].name
+}
+
+// UsbProduct returns the name of a usb product, empty if it is unknown.
+func UsbProduct(vendorId, productId string) string {
+	return usbDatabase().vendors[normalize(vendorId)].devices[normalize(productId)].name
+}
+
+// PciVendor returns the name of a pci vendor id such as "0x10de" or "10de", empty if it is unknown.
+func PciVendor(vendorId string) string {
+	return pciDatabase().vendors[normalize(vendorId)].name
+}
+
+// PciDevice returns the name of a pci device, empty if it is unknown.
+func PciDevice(vendorId, deviceId string) string {
+	return pciDatabase().vendors[normalize(vendorId)].devices[normalize(deviceId)].name
+}
+
+// PciSubsystem returns the name of the subsystem of a pci device, empty if it is unknown.
+func PciSubsystem(vendorId, deviceId, subVendorId, subDeviceId string) string {
+	d := pciDatabase().vendors[normalize(vendorId)].devices[normalize(deviceId)]
+	return d.subsystems[normalize(subVendorId)+" "+normalize(subDeviceId)]
+}
+
+// PciClass returns the most specific name of a class code such as "0x030000", which holds the class,
+// subclass and programming interface. Codes of 2 or 4 digits name a class or subclass.
+func PciClass(classCode string) string {
+	code := normalize(classCode)
+	classes := pciDatabase().classes
+	for len(code) >= 2 {
+		if name, exists := classes[code]; exists {
+			return name
+		}
+		code = code[:len(code)-2]
+	}
+	return ""
+}
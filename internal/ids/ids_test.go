@@ -0,0 +1,128 @@
+/*
+ * Copyright 2016-2024 The OSHI Project Contributors
+ * SPDX-License-Identifier: MIT
+ */
+
+package ids
+
+import (
+	"reflect"
+	"testing"
+)
+
+const sampleIds = `#
+#	List of PCI ID's
+#
+# Version: 2025.03.04
+#
+
+# Syntax:
+# vendor  vendor_name
+#	device  device_name				<-- single tab
+#		subvendor subdevice  subsystem_name	<-- two tabs
+
+8086  Intel Corporation
+	51c8  Alder Lake PCH-P High Definition Audio Controller
+		1028 0b10  Precision 3571
+	A0E0  Tiger Lake-LP Management Engine Interface
+10DE  NVIDIA Corporation
+	25a2  GA107M [GeForce RTX 3050 Mobile]
+
+# List of known device classes, subclasses and programming interfaces
+
+C 03  Display controller
+	00  VGA compatible controller
+		00  VGA controller
+	02  3D controller
+C 0c  Serial bus controller
+	03  USB controller
+		30  XHCI
+
+# The lists after the classes of usb.ids
+HID 21  HID
+HID 22  Report
+R 04  Usage Page
+R 08  Usage
+HUT 01  Generic Desktop Controls
+	000  Undefined
+	001  Pointer
+L 0000  Unknown
+`
+
+func TestParseIds(t *testing.T) {
+	db := parseIds(sampleIds)
+	vendors := make(map[string]string)
+	for id, v := range db.vendors {
+		vendors[id] = v.name
+	}
+	// ids are lower case, the HID descriptor item types such as "R 04" are not vendors
+	if want := map[string]string{"8086": "Intel Corporation", "10de": "NVIDIA Corporation"}; !reflect.DeepEqual(vendors, want) {
+		t.Errorf("vendors = %v, want %v", vendors, want)
+	}
+	intel := db.vendors["8086"]
+	if name := intel.devices["a0e0"].name; name != "Tiger Lake-LP Management Engine Interface" {
+		t.Errorf("device a0e0 = %q", name)
+	}
+	// subsystems are keyed by subvendor and subdevice
+	if name := intel.devices["51c8"].subsystems["1028 0b10"]; name != "Precision 3571" {
+		t.Errorf("subsystem 1028 0b10 = %q", name)
+	}
+	// classes are keyed by class, class and subclass, and class, subclass and programming interface
+	want := map[string]string{
+		"03":     "Display controller",
+		"0300":   "VGA compatible controller",
+		"030000": "VGA controller",
+		"0302":   "3D controller",
+		"0c":     "Serial bus controller",
+		"0c03":   "USB controller",
+		"0c0330": "XHCI",
+	}
+	if !reflect.DeepEqual(db.classes, want) {
+		t.Errorf("classes = %v, want %v", db.classes, want)
+	}
+}
+
+func TestPciLookups(t *testing.T) {
+	tests := []struct {
+		name, got, want string
+	}{
+		{"vendor", PciVendor("0x10DE"), "NVIDIA Corporation"},
+		{"vendor without prefix", PciVendor("8086"), "Intel Corporation"},
+		{"device", PciDevice("0x10de", "0x25a2"), "GA107M [GeForce RTX 3050 Mobile]"},
+		{"subsystem", PciSubsystem("0x8086", "0x51c8", "0x1028", "0x0b10"), "Precision 3571"},
+		{"unknown device", PciDevice("0x8086", "0xffff"), ""},
+		{"class", PciClass("0x03"), "Display controller"},
+		{"subclass", PciClass("0x0302"), "3D controller"},
+		{"programming interface", PciClass("0x0c0330"), "XHCI"},
+		// an unknown programming interface falls back to its subclass
+		{"unknown programming interface", PciClass("0x0c03ff"), "USB controller"},
+		{"unknown class", PciClass("0xfe0000"), ""},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("%s = %q, want %q", tt.name, tt.got, tt.want)
+		}
+	}
+}
+
+func TestUsbLookups(t *testing.T) {
+	tests := []struct {
+		name, got, want string
+	}{
+		{"vendor", UsbVendor("046D"), "Logitech, Inc."},
+		{"product", UsbProduct("046d", "c52b"), "Unifying Receiver"},
+		{"unknown product", UsbProduct("8087", "ffff"), ""},
+		// the HID descriptor item types after the classes are not vendors
+		{"item type", UsbVendor("R 04"), ""},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("%s = %q, want %q", tt.name, tt.got, tt.want)
+		}
+	}
+	for id, v := range usbDatabase().vendors {
+		if !isVendorId(id) {
+			t.Errorf("vendor %q (%s) is not a vendor id", id, v.name)
+		}
+	}
+}
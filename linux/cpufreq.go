@@ -0,0 +1,145 @@
+/*
+ * Copyright 2016-2024 The OSHI Project Contributors
+ * SPDX-License-Identifier: MIT
+ */
+
+package linux
+
+import (
+	"fmt"
+	"goshi/sysinfo/hardware"
+	"goshi/util"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var (
+	policyDirRegex = regexp.MustCompile(`^policy[0-9]+$`)
+)
+
+// readKHz reads a cpufreq frequency in kHz and returns it in Hz, -1 if unavailable
+func readKHz(path string) int64 {
+	if khz := util.ReadInt64OrDefault(path, -1); khz > 0 {
+		return khz * 1000
+	}
+	return -1
+}
+
+// readBoost returns 1 if the flag at path is set, 0 if cleared and -1 if unavailable
+func readBoost(path string, inverted bool) int {
+	val := util.ReadIntOrDefault(path, -1)
+	if val < 0 {
+		return -1
+	}
+	if (val != 0) != inverted {
+		return 1
+	}
+	return 0
+}
+
+// globalBoost reads the boost state of the acpi-cpufreq driver, or the turbo state of intel_pstate
+func globalBoost() int {
+	if boost := readBoost(filepath.Join(rootPath(sysCpuFreq), "boost"), false); boost >= 0 {
+		return boost
+	}
+	return readBoost(filepath.Join(rootPath(sysPState), "no_turbo"), true)
+}
+
+func frequencyPolicies() []hardware.FrequencyPolicy {
+	policies := make([]hardware.FrequencyPolicy, 0)
+	entries, err := os.ReadDir(rootPath(sysCpuFreq))
+	if err != nil {
+		return policies
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		a, _ := strconv.Atoi(strings.TrimPrefix(entries[i].Name(), "policy"))
+		b, _ := strconv.Atoi(strings.TrimPrefix(entries[j].Name(), "policy"))
+		return a < b
+	})
+	boost := globalBoost()
+	for _, entry := range entries {
+		if !policyDirRegex.MatchString(entry.Name()) {
+			continue
+		}
+		policy := filepath.Join(rootPath(sysCpuFreq), entry.Name())
+		cpus := util.ReadString(filepath.Join(policy, "related_cpus"))
+		if len(cpus) == 0 {
+			cpus = util.ReadString(filepath.Join(policy, "affected_cpus"))
+		}
+		policyBoost := readBoost(filepath.Join(policy, "boost"), false)
+		if policyBoost < 0 {
+			policyBoost = boost
+		}
+		policies = append(policies, hardware.NewFrequencyPolicy(
+			entry.Name(),
+			util.StringValueOrDefault(util.ReadString(filepath.Join(policy, "scaling_driver")), util.Unknown),
+			util.StringValueOrDefault(util.ReadString(filepath.Join(policy, "scaling_governor")), util.Unknown),
+			util.ParseIntList(strings.ReplaceAll(cpus, " ", ",")),
+			readKHz(filepath.Join(policy, "cpuinfo_min_freq")),
+			readKHz(filepath.Join(policy, "cpuinfo_max_freq")),
+			readKHz(filepath.Join(policy, "scaling_min_freq")),
+			readKHz(filepath.Join(policy, "scaling_max_freq")),
+			policyBoost,
+		))
+	}
+	return policies
+}
+
+func maxFreq(logProcs []logicalProcessor, procId hardware.ProcessorIdentifier) int64 {
+	maxFreq := int64(-1)
+	for _, logProc := range logProcs {
+		path := filepath.Join(rootPath(sysCpu), fmt.Sprintf("cpu%d", logProc.processorNumber), "cpufreq", "cpuinfo_max_freq")
+		maxFreq = max(maxFreq, readKHz(path))
+	}
+	if maxFreq < 0 && procId.Frequency() > 0 {
+		return procId.Frequency()
+	}
+	return maxFreq
+}
+
+// cpuInfoFreq maps every logical processor to the "cpu MHz" of /proc/cpuinfo in Hz
+func cpuInfoFreq() map[int]int64 {
+	freqs := make(map[int]int64)
+	cur := -1
+	for _, line := range util.ReadLines(rootPath(procCpuInfo)) {
+		key, value, ok := splitCpuInfoLine(line)
+		if !ok {
+			continue
+		}
+		switch key {
+		case "processor":
+			if num, err := strconv.Atoi(value); err == nil {
+				cur = num
+			}
+		case "cpu mhz":
+			if mhz, err := strconv.ParseFloat(value, 64); err == nil && cur >= 0 {
+				freqs[cur] = int64(mhz * 1_000_000)
+			}
+		default:
+		}
+	}
+	return freqs
+}
+
+func currentFreq(logProcs []logicalProcessor) []int64 {
+	freqs := make([]int64, len(logProcs))
+	var fallback map[int]int64
+	for i, logProc := range logProcs {
+		path := filepath.Join(rootPath(sysCpu), fmt.Sprintf("cpu%d", logProc.processorNumber), "cpufreq", "scaling_cur_freq")
+		freqs[i] = readKHz(path)
+		if freqs[i] > 0 {
+			continue
+		}
+		if fallback == nil {
+			fallback = cpuInfoFreq()
+		}
+		if freq, exists := fallback[logProc.processorNumber]; exists {
+			freqs[i] = freq
+		}
+	}
+	return freqs
+}
@@ -0,0 +1,186 @@
+/*
+ * Copyright 2016-2024 The OSHI Project Contributors
+ * SPDX-License-Identifier: MIT
+ */
+
+package linux
+
+import (
+	"errors"
+	"fmt"
+	"goshi/sysinfo/hardware"
+	"goshi/util"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// sysfs sizes and the /proc/diskstats sector counters are in 512 byte units regardless of the device
+	sectorSize = 512
+)
+
+type LinuxHWDiskStore struct {
+	kernelName, name, model, serial             string
+	size                                        int64
+	reads, readBytes, writes, writeBytes        int64
+	currentQueueLength, transferTime, timeStamp int64
+	partitions                                  []hardware.HWPartition
+}
+
+func (l *LinuxHWDiskStore) Name() string {
+	return l.name
+}
+
+func (l *LinuxHWDiskStore) Model() string {
+	return l.model
+}
+
+func (l *LinuxHWDiskStore) Serial() string {
+	return l.serial
+}
+
+func (l *LinuxHWDiskStore) Size() int64 {
+	return l.size
+}
+
+func (l *LinuxHWDiskStore) Reads() int64 {
+	return l.reads
+}
+
+func (l *LinuxHWDiskStore) ReadBytes() int64 {
+	return l.readBytes
+}
+
+func (l *LinuxHWDiskStore) Writes() int64 {
+	return l.writes
+}
+
+func (l *LinuxHWDiskStore) WriteBytes() int64 {
+	return l.writeBytes
+}
+
+func (l *LinuxHWDiskStore) CurrentQueueLength() int64 {
+	return l.currentQueueLength
+}
+
+func (l *LinuxHWDiskStore) TransferTime() int64 {
+	return l.transferTime
+}
+
+func (l *LinuxHWDiskStore) Partitions() []hardware.HWPartition {
+	return l.partitions
+}
+
+func (l *LinuxHWDiskStore) TimeStamp() int64 {
+	return l.timeStamp
+}
+
+func (l *LinuxHWDiskStore) UpdateAttributes() bool {
+	stats, exists := readDiskStats()[l.kernelName]
+	if !exists {
+		return false
+	}
+	l.setStats(stats)
+	return true
+}
+
+// setStats copies the counters of a /proc/diskstats line, fields after the device name
+func (l *LinuxHWDiskStore) setStats(stats []int64) {
+	l.reads = stats[0]
+	l.readBytes = stats[2] * sectorSize
+	l.writes = stats[4]
+	l.writeBytes = stats[6] * sectorSize
+	l.currentQueueLength = stats[8]
+	l.transferTime = stats[9]
+	l.timeStamp = time.Now().UnixMilli()
+}
+
+// readDiskStats maps kernel device names to their first 11 counters
+func readDiskStats() map[string][]int64 {
+	stats := make(map[string][]int64)
+	for _, line := range util.ReadLines(rootPath(procDiskStats)) {
+		fields := strings.Fields(line)
+		if len(fields) < 14 {
+			continue
+		}
+		counters := make([]int64, 11)
+		for i := range counters {
+			counters[i] = util.ParseInt64OrDefault(fields[i+3], 0)
+		}
+		stats[fields[2]] = counters
+	}
+	return stats
+}
+
+func diskModel(dev string, udev map[string]string) string {
+	if model := util.ReadString(filepath.Join(dev, "device", "model")); len(model) != 0 {
+		return model
+	}
+	if model, exists := udev["ID_MODEL"]; exists {
+		return strings.ReplaceAll(model, "_", " ")
+	}
+	if _, err := os.Stat(filepath.Join(dev, "dm")); err == nil {
+		return "Logical Volume"
+	}
+	return util.Unknown
+}
+
+func diskSerial(dev string, udev map[string]string) string {
+	if serial, exists := udev["ID_SERIAL_SHORT"]; exists {
+		return serial
+	}
+	if serial := util.ReadString(filepath.Join(dev, "device", "serial")); len(serial) != 0 {
+		return serial
+	}
+	if uuid := util.ReadString(filepath.Join(dev, "dm", "uuid")); len(uuid) != 0 {
+		return uuid
+	}
+	return util.Unknown
+}
+
+func DiskStores() ([]hardware.HWDiskStore, error) {
+	block := rootPath(sysBlock)
+	entries, err := os.ReadDir(block)
+	if errors.Is(err, fs.ErrNotExist) {
+		return make([]hardware.HWDiskStore, 0), nil
+	} else if err != nil {
+		return nil, fmt.Errorf("disk: failed to read %s: %w", block, err)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Name() < entries[j].Name()
+	})
+	stats := readDiskStats()
+	partitions := diskPartitions()
+	disks := make([]hardware.HWDiskStore, 0)
+	for _, entry := range entries {
+		name := entry.Name()
+		// loop and ram disks are not backed by storage
+		if strings.HasPrefix(name, "loop") || strings.HasPrefix(name, "ram") {
+			continue
+		}
+		dev := filepath.Join(block, name)
+		major, minor := parseDevNumber(util.ReadString(filepath.Join(dev, "dev")))
+		udev := udevProperties(udevBlockDevice(major, minor))
+		disk := &LinuxHWDiskStore{
+			kernelName: name,
+			name:       "/dev/" + name,
+			model:      diskModel(dev, udev),
+			serial:     diskSerial(dev, udev),
+			size:       util.ReadInt64OrDefault(filepath.Join(dev, "size"), 0) * sectorSize,
+			partitions: partitions[name],
+			timeStamp:  time.Now().UnixMilli(),
+		}
+		if disk.partitions == nil {
+			disk.partitions = make([]hardware.HWPartition, 0)
+		}
+		if counters, exists := stats[name]; exists {
+			disk.setStats(counters)
+		}
+		disks = append(disks, disk)
+	}
+	return disks, nil
+}
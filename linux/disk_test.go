@@ -0,0 +1,109 @@
+/*
+ * Copyright 2016-2024 The OSHI Project Contributors
+ * SPDX-License-Identifier: MIT
+ */
+
+package linux
+
+import (
+	"goshi/sysinfo/hardware"
+	"goshi/util"
+	"reflect"
+	"testing"
+)
+
+func TestDiskStores(t *testing.T) {
+	type disk struct {
+		name, model, serial                                             string
+		size, reads, readBytes, writes, writeBytes, queue, transferTime int64
+	}
+	tests := []struct {
+		tree  string
+		disks []disk
+	}{
+		{
+			tree: "x86-hybrid",
+			// loop0 is skipped
+			disks: []disk{
+				{"/dev/nvme0n1", "Samsung SSD 980 PRO 1TB", "S5GXNF0R123456", 1000215216 * 512, 120000, 9600000 * 512, 80000, 6400000 * 512, 2, 50000},
+				// the model of udev has underscores for spaces
+				{"/dev/sda", "Ultra Fit", "4C530001234567891234", 60063744 * 512, 500, 64000 * 512, 20, 2048 * 512, 0, 1000},
+			},
+		},
+		{
+			tree: "arm64-tri-cluster",
+			disks: []disk{
+				{"/dev/mmcblk0", util.Unknown, "0x1234abcd", 244277248 * 512, 9000, 720000 * 512, 3000, 240000 * 512, 0, 7000},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.tree, func(t *testing.T) {
+			setFixtureRoot(t, tt.tree)
+			stores, err := DiskStores()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(stores) != len(tt.disks) {
+				t.Fatalf("got %d disks, want %d", len(stores), len(tt.disks))
+			}
+			for i, s := range stores {
+				got := disk{s.Name(), s.Model(), s.Serial(), s.Size(), s.Reads(), s.ReadBytes(), s.Writes(), s.WriteBytes(), s.CurrentQueueLength(), s.TransferTime()}
+				if got != tt.disks[i] {
+					t.Errorf("disk = %+v, want %+v", got, tt.disks[i])
+				}
+				if s.TimeStamp() <= 0 {
+					t.Errorf("%s has no time stamp", s.Name())
+				}
+				if !s.UpdateAttributes() {
+					t.Errorf("%s is gone after an update", s.Name())
+				}
+			}
+		})
+	}
+}
+
+func TestDiskPartitions(t *testing.T) {
+	tests := []struct {
+		tree       string
+		partitions [][]hardware.HWPartition
+	}{
+		{
+			tree: "x86-hybrid",
+			partitions: [][]hardware.HWPartition{
+				{
+					hardware.NewHWPartition("/dev/nvme0n1p1", "nvme0n1p1", "vfat", "0c8a9e1e-6d7c-4f2a-9d8e-2f3b4c5d6e7f", 524288<<10, 259, 1, "/boot/efi"),
+					// the root subvolume, not /home or the bind mount of /var/lib/docker
+					hardware.NewHWPartition("/dev/nvme0n1p2", "nvme0n1p2", "btrfs", "7f3e2d1c-0b9a-4876-8543-210fedcba987", 499582279<<10, 259, 2, "/"),
+				},
+				// no udev data, the type comes from the mount
+				{hardware.NewHWPartition("/dev/sda1", "sda1", "vfat", util.Unknown, 30030848<<10, 8, 1, "/media/user/USB STICK")},
+			},
+		},
+		{
+			tree: "arm64-tri-cluster",
+			partitions: [][]hardware.HWPartition{{
+				// the bind mount of /data comes first but does not replace the root mount
+				hardware.NewHWPartition("/dev/mmcblk0p1", "mmcblk0p1", "ext4", util.Unknown, 118138624<<10, 179, 1, "/"),
+				hardware.NewHWPartition("/dev/mmcblk0p2", "mmcblk0p2", "swap", "0a1b2c3d-4e5f-6071-8293-a4b5c6d7e8f9", 4000000<<10, 179, 2, ""),
+			}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.tree, func(t *testing.T) {
+			setFixtureRoot(t, tt.tree)
+			stores, err := DiskStores()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(stores) != len(tt.partitions) {
+				t.Fatalf("got %d disks, want %d", len(stores), len(tt.partitions))
+			}
+			for i, s := range stores {
+				if !reflect.DeepEqual(s.Partitions(), tt.partitions[i]) {
+					t.Errorf("%s partitions = %+v, want %+v", s.Name(), s.Partitions(), tt.partitions[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,28 @@
+/*
+ * Copyright 2016-2024 The OSHI Project Contributors
+ * SPDX-License-Identifier: MIT
+ */
+
+package linux
+
+import (
+	"goshi/sysinfo/hardware"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+func Displays() []hardware.Display {
+	displays := make([]hardware.Display, 0)
+	// connectors such as card0-HDMI-A-1 have an empty edid while nothing is plugged in
+	files, _ := filepath.Glob(filepath.Join(rootPath(sysClassDrm), "card*-*", "edid"))
+	sort.Strings(files)
+	for _, file := range files {
+		edid, err := os.ReadFile(file)
+		if err != nil || len(edid) == 0 {
+			continue
+		}
+		displays = append(displays, hardware.NewDisplay(edid))
+	}
+	return displays
+}
@@ -0,0 +1,44 @@
+/*
+ * Copyright 2016-2024 The OSHI Project Contributors
+ * SPDX-License-Identifier: MIT
+ */
+
+package linux
+
+import (
+	"goshi/util/edid"
+	"reflect"
+	"testing"
+)
+
+func TestDisplays(t *testing.T) {
+	tests := []struct {
+		tree string
+		// the decoded EDID of every connected display
+		want []string
+	}{
+		// the laptop panel and a monitor on hdmi, not the empty DisplayPort connector
+		{"x86-hybrid", []string{
+			"BOE  (0A3E), EDID 1.4, 31x17 cm, 1920x1080@60.05Hz",
+			"DEL DELL U2720Q (A0C5), EDID 1.4, 60x34 cm, 1920x1080@60.00Hz",
+		}},
+		// the dsi panel has no edid
+		{"arm64-tri-cluster", []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.tree, func(t *testing.T) {
+			setFixtureRoot(t, tt.tree)
+			got := make([]string, 0)
+			for _, display := range Displays() {
+				decoded, err := edid.Decode(display.Edid())
+				if err != nil {
+					t.Fatal(err)
+				}
+				got = append(got, decoded.String())
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("displays = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
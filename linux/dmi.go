@@ -0,0 +1,32 @@
+/*
+ * Copyright 2016-2024 The OSHI Project Contributors
+ * SPDX-License-Identifier: MIT
+ */
+
+package linux
+
+import (
+	"errors"
+	"fmt"
+	"goshi/util/smbios"
+	"io/fs"
+	"os"
+)
+
+func readSmbiosTable() (smbios.Table, error) {
+	path := rootPath(sysDmiTable)
+	table, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrPermission) {
+		return smbios.Table{}, fmt.Errorf("dmi: reading %s requires root privileges: %w", path, err)
+	} else if err != nil {
+		return smbios.Table{}, fmt.Errorf("dmi: failed to read %s: %w", path, err)
+	}
+	// the version only matters to a few fields, a missing or invalid entry point leaves it unset
+	// and the structures are still decoded
+	entryPoint, _ := os.ReadFile(rootPath(sysDmiEntry))
+	t, err := smbios.Parse(entryPoint, table)
+	if err != nil && !errors.Is(err, smbios.ErrInvalidEntryPoint) {
+		return smbios.Table{}, fmt.Errorf("dmi: %w", err)
+	}
+	return t, nil
+}
@@ -0,0 +1,110 @@
+/*
+ * Copyright 2016-2024 The OSHI Project Contributors
+ * SPDX-License-Identifier: MIT
+ */
+
+package linux
+
+import (
+	"goshi/util"
+	"os"
+	"runtime"
+)
+
+var (
+	// https://github.com/torvalds/linux/blob/master/arch/arm64/include/uapi/asm/hwcap.h
+	arm64Hwcap = []string{
+		"fp", "asimd", "evtstrm", "aes", "pmull", "sha1", "sha2", "crc32",
+		"atomics", "fphp", "asimdhp", "cpuid", "asimdrdm", "jscvt", "fcma", "lrcpc",
+		"dcpop", "sha3", "sm3", "sm4", "asimddp", "sha512", "sve", "asimdfhm",
+		"dit", "uscat", "ilrcpc", "flagm", "ssbs", "sb", "paca", "pacg",
+	}
+	arm64Hwcap2 = []string{
+		"dcpodp", "sve2", "sveaes", "svepmull", "svebitperm", "svesha3", "svesm4", "flagm2",
+		"frint", "svei8mm", "svef32mm", "svef64mm", "svebf16", "i8mm", "bf16", "dgh",
+		"rng", "bti", "mte",
+	}
+	// https://github.com/torvalds/linux/blob/master/arch/arm/include/uapi/asm/hwcap.h
+	armHwcap = []string{
+		"swp", "half", "thumb", "26bit", "fastmult", "fpa", "vfp", "edsp",
+		"java", "iwmmxt", "crunch", "thumbee", "neon", "vfpv3", "vfpv3d16", "tls",
+		"vfpv4", "idiva", "idivt", "vfpd32", "lpae", "evtstrm",
+	}
+	armHwcap2 = []string{
+		"aes", "pmull", "sha1", "sha2", "crc32",
+	}
+	x86Hwcap2 = []string{
+		"ring3mwait", "fsgsbase",
+	}
+)
+
+// readAuxv returns the entries of the auxiliary vector of the current process. The vector is
+// decoded with the word size and byte order of this process and its bits are interpreted for this
+// architecture, so it is only read on the live system and never from a captured filesystem tree.
+func readAuxv() map[uint64]uint64 {
+	auxv := make(map[uint64]uint64)
+	if Root() != "/" {
+		return auxv
+	}
+	b, err := os.ReadFile(procAuxv)
+	if err != nil {
+		return auxv
+	}
+	order := util.HostByteOrder()
+	word := util.Bits / 8
+	for off := 0; off+2*word <= len(b); off += 2 * word {
+		var key, val uint64
+		if word == 8 {
+			key, val = order.Uint64(b[off:]), order.Uint64(b[off+word:])
+		} else {
+			key, val = uint64(order.Uint32(b[off:])), uint64(order.Uint32(b[off+word:]))
+		}
+		if key == 0 {
+			break
+		}
+		auxv[key] = val
+	}
+	return auxv
+}
+
+func decodeHwcap(hwcap uint64, names []string) []string {
+	features := make([]string, 0)
+	for bit, name := range names {
+		if hwcap&(1<<bit) != 0 {
+			features = append(features, name)
+		}
+	}
+	return features
+}
+
+// hwcapFeatures decodes the AT_HWCAP and AT_HWCAP2 bits the kernel reports for this architecture
+func hwcapFeatures() []string {
+	auxv := readAuxv()
+	hwcap, hwcap2 := auxv[util.AT_HWCAP], auxv[util.AT_HWCAP2]
+	features := make([]string, 0)
+	switch runtime.GOARCH {
+	case "arm64":
+		features = append(features, decodeHwcap(hwcap, arm64Hwcap)...)
+		features = append(features, decodeHwcap(hwcap2, arm64Hwcap2)...)
+	case "arm":
+		features = append(features, decodeHwcap(hwcap, armHwcap)...)
+		features = append(features, decodeHwcap(hwcap2, armHwcap2)...)
+	case "386", "amd64":
+		// AT_HWCAP holds the edx register of cpuid leaf 1
+		for flag, bit := range cpuidEdxFlags {
+			if hwcap&(1<<bit) != 0 {
+				features = append(features, flag)
+			}
+		}
+		features = append(features, decodeHwcap(hwcap2, x86Hwcap2)...)
+	case "riscv64":
+		// one bit per single letter extension
+		for c := 'a'; c <= 'z'; c++ {
+			if hwcap&(1<<(c-'a')) != 0 {
+				features = append(features, string(c))
+			}
+		}
+	default:
+	}
+	return features
+}
@@ -0,0 +1,133 @@
+/*
+ * Copyright 2016-2024 The OSHI Project Contributors
+ * SPDX-License-Identifier: MIT
+ */
+
+package linux
+
+import (
+	"errors"
+	"fmt"
+	set "github.com/deckarep/golang-set/v2"
+	"goshi/internal/ids"
+	"goshi/sysinfo/hardware"
+	"goshi/util"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+var (
+	drmCardRegex = regexp.MustCompile(`^card[0-9]+$`)
+)
+
+type LinuxGraphicsCard struct {
+	name, deviceId, vendor, versionInfo string
+	vRam                                int64
+}
+
+func (l LinuxGraphicsCard) Name() string {
+	return l.name
+}
+
+func (l LinuxGraphicsCard) DeviceId() string {
+	return l.deviceId
+}
+
+func (l LinuxGraphicsCard) Vendor() string {
+	return l.vendor
+}
+
+func (l LinuxGraphicsCard) VersionInfo() string {
+	return l.versionInfo
+}
+
+func (l LinuxGraphicsCard) VRam() int64 {
+	return l.vRam
+}
+
+// parsePCIID normalizes a sysfs pci id such as "0x10DE" to the format of parseGPUDeviceID on windows
+func parsePCIID(id string) string {
+	id = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(id), "0x"))
+	if len(id) == 0 {
+		return util.Unknown
+	}
+	return "0x" + id
+}
+
+// pciVendorName names a vendor id as the windows implementation does, "NVIDIA Corporation (0x10de)"
+func pciVendorName(vendorId string) string {
+	if name := ids.PciVendor(vendorId); len(name) != 0 {
+		return fmt.Sprintf("%s (%s)", name, vendorId)
+	}
+	return vendorId
+}
+
+func gpuVersionInfo(device string) string {
+	driverPath, err := os.Readlink(filepath.Join(device, "driver"))
+	if err != nil {
+		return util.Unknown
+	}
+	driver := filepath.Base(driverPath)
+	info := []string{fmt.Sprintf("Driver=%s", driver)}
+	if version := util.ReadString(filepath.Join(rootPath(sysModule), driver, "version")); len(version) != 0 {
+		info = append(info, fmt.Sprintf("DriverVersion=%s", version))
+	}
+	return strings.Join(info, ", ")
+}
+
+func gpuVRam(card, device string) int64 {
+	// amdgpu
+	if vram := util.ReadInt64OrDefault(filepath.Join(device, "mem_info_vram_total"), 0); vram > 0 {
+		return vram
+	}
+	// i915 discrete cards
+	return util.ReadInt64OrDefault(filepath.Join(card, "lmem_total_bytes"), 0)
+}
+
+func GPUs() ([]hardware.GraphicsCard, error) {
+	drm := rootPath(sysClassDrm)
+	entries, err := os.ReadDir(drm)
+	if errors.Is(err, fs.ErrNotExist) {
+		// no drm devices
+		return make([]hardware.GraphicsCard, 0), nil
+	} else if err != nil {
+		return nil, fmt.Errorf("drm: failed to read %s: %w", drm, err)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Name() < entries[j].Name()
+	})
+	devices := set.NewSet[string]()
+	gpus := make([]hardware.GraphicsCard, 0)
+	for _, entry := range entries {
+		if !drmCardRegex.MatchString(entry.Name()) {
+			continue
+		}
+		card := filepath.Join(drm, entry.Name())
+		device := filepath.Join(card, "device")
+		resolved, err := filepath.EvalSymlinks(device)
+		if err != nil {
+			continue
+		}
+		// a device may be exposed through more than one card node
+		if !devices.Add(resolved) {
+			continue
+		}
+		vendorId := parsePCIID(util.ReadString(filepath.Join(device, "vendor")))
+		deviceId := parsePCIID(util.ReadString(filepath.Join(device, "device")))
+		// amdgpu reports the marketing name, others only have their ids
+		name := util.StringValueOrDefault(util.ReadString(filepath.Join(device, "product_name")), ids.PciDevice(vendorId, deviceId))
+		gpu := LinuxGraphicsCard{
+			name:        util.StringValueOrDefault(name, util.Unknown),
+			deviceId:    deviceId,
+			vendor:      pciVendorName(vendorId),
+			versionInfo: gpuVersionInfo(device),
+			vRam:        gpuVRam(card, device),
+		}
+		gpus = append(gpus, gpu)
+	}
+	return gpus, nil
+}
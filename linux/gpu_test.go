@@ -0,0 +1,62 @@
+/*
+ * Copyright 2016-2024 The OSHI Project Contributors
+ * SPDX-License-Identifier: MIT
+ */
+
+package linux
+
+import (
+	"goshi/util"
+	"reflect"
+	"testing"
+)
+
+func TestGPUs(t *testing.T) {
+	tests := []struct {
+		tree string
+		want []LinuxGraphicsCard
+	}{
+		{
+			tree: "x86-hybrid",
+			// the connector and render nodes are skipped, names come from pci.ids
+			want: []LinuxGraphicsCard{
+				{"Alder Lake-P GT2 [Iris Xe Graphics]", "0x46a6", "Intel Corporation (0x8086)", "Driver=i915", 0},
+				{"GA107M [GeForce RTX 3050 Mobile]", "0x25a2", "NVIDIA Corporation (0x10de)", "Driver=nvidia, DriverVersion=550.120", 0},
+			},
+		},
+		{
+			tree: "arm64-tri-cluster",
+			// a platform display controller has no pci ids
+			want: []LinuxGraphicsCard{{util.Unknown, util.Unknown, util.Unknown, "Driver=msm_dpu", 0}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.tree, func(t *testing.T) {
+			setFixtureRoot(t, tt.tree)
+			gpus, err := GPUs()
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := make([]LinuxGraphicsCard, 0)
+			for _, gpu := range gpus {
+				got = append(got, gpu.(LinuxGraphicsCard))
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("graphics cards = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParsePCIID(t *testing.T) {
+	tests := map[string]string{
+		"0x10DE\n": "0x10de",
+		"8086":     "0x8086",
+		"":         util.Unknown,
+	}
+	for id, want := range tests {
+		if got := parsePCIID(id); got != want {
+			t.Errorf("parsePCIID(%q) = %s, want %s", id, got, want)
+		}
+	}
+}
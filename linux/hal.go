@@ -0,0 +1,108 @@
+/*
+ * Copyright 2016-2024 The OSHI Project Contributors
+ * SPDX-License-Identifier: MIT
+ */
+
+package linux
+
+import (
+	"goshi/sysinfo/hardware"
+	"goshi/util"
+	"sync"
+)
+
+type LinuxHardwareAbstractionLayer struct {
+	computerSystem func() (hardware.ComputerSystem, error)
+	processor      func() (hardware.CentralProcessor, error)
+	memory         func() (hardware.GlobalMemory, error)
+	graphicsCards  func() ([]hardware.GraphicsCard, error)
+	sensors        func() (hardware.Sensors, error)
+}
+
+// memoizeByRoot is like util.MemoizeWithError with a ttl that never expires, except that the value
+// is read again once SetRoot points the readers at another root
+func memoizeByRoot[T any](supplier func() (T, error)) func() (T, error) {
+	var mu sync.Mutex
+	var value T
+	var root string
+	valid := false
+	return func() (T, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if current := Root(); !valid || current != root {
+			val, err := supplier()
+			if err != nil {
+				valid = false
+				return val, err
+			}
+			value, root, valid = val, current, true
+		}
+		return value, nil
+	}
+}
+
+func (l LinuxHardwareAbstractionLayer) ComputerSystem() (hardware.ComputerSystem, error) {
+	return l.computerSystem()
+}
+
+func (l LinuxHardwareAbstractionLayer) Processor() (hardware.CentralProcessor, error) {
+	return l.processor()
+}
+
+func (l LinuxHardwareAbstractionLayer) Memory() (hardware.GlobalMemory, error) {
+	return l.memory()
+}
+
+func (l LinuxHardwareAbstractionLayer) GraphicsCards() ([]hardware.GraphicsCard, error) {
+	return l.graphicsCards()
+}
+
+func (l LinuxHardwareAbstractionLayer) PciDevices() ([]hardware.PciDevice, error) {
+	return PciDevices()
+}
+
+func (l LinuxHardwareAbstractionLayer) DiskStores() ([]hardware.HWDiskStore, error) {
+	return DiskStores()
+}
+
+func (l LinuxHardwareAbstractionLayer) NetworkIFs(includeLocal bool) ([]hardware.NetworkIF, error) {
+	return NetworkIFs(includeLocal)
+}
+
+func (l LinuxHardwareAbstractionLayer) Sensors() (hardware.Sensors, error) {
+	return l.sensors()
+}
+
+func (l LinuxHardwareAbstractionLayer) PowerSources() ([]hardware.PowerSource, error) {
+	return PowerSources(), nil
+}
+
+func (l LinuxHardwareAbstractionLayer) UsbDevices(tree bool) ([]hardware.UsbDevice, error) {
+	return UsbDevices(tree)
+}
+
+func (l LinuxHardwareAbstractionLayer) SoundCards() ([]hardware.SoundCard, error) {
+	return SoundCards(), nil
+}
+
+func (l LinuxHardwareAbstractionLayer) Displays() ([]hardware.Display, error) {
+	return Displays(), nil
+}
+
+// HardwareAbstractionLayer returns the linux hardware. The computer system, processor, memory and
+// sensors are read once for every root set through SetRoot.
+func HardwareAbstractionLayer() hardware.HardwareAbstractionLayer {
+	return LinuxHardwareAbstractionLayer{
+		computerSystem: memoizeByRoot(func() (hardware.ComputerSystem, error) {
+			return ComputerSystem(), nil
+		}),
+		processor: memoizeByRoot(Processor),
+		memory: memoizeByRoot(func() (hardware.GlobalMemory, error) {
+			return GlobalMemory(), nil
+		}),
+		graphicsCards: util.MemoizeWithError(GPUs, util.DefaultExpiration()),
+		sensors: memoizeByRoot(func() (hardware.Sensors, error) {
+			return Sensors(), nil
+		}),
+	}
+}
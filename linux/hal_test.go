@@ -0,0 +1,38 @@
+/*
+ * Copyright 2016-2024 The OSHI Project Contributors
+ * SPDX-License-Identifier: MIT
+ */
+
+package linux
+
+import "testing"
+
+func TestHardwareAbstractionLayerFollowsRoot(t *testing.T) {
+	hal := HardwareAbstractionLayer()
+	tests := []struct {
+		tree    string
+		vendor  string
+		logical int
+		total   int64
+	}{
+		{"x86-hybrid", "GenuineIntel", 4, 16131016 << 10},
+		{"arm64-tri-cluster", "ARM", 8, 3884836 << 10},
+		{"x86-hybrid", "GenuineIntel", 4, 16131016 << 10},
+	}
+	for _, tt := range tests {
+		setFixtureRoot(t, tt.tree)
+		p, err := hal.Processor()
+		if err != nil {
+			t.Fatal(err)
+		}
+		m, err := hal.Memory()
+		if err != nil {
+			t.Fatal(err)
+		}
+		id := p.ProcessorIdentifier()
+		if id.Vendor() != tt.vendor || p.LogicalProcessorCount() != tt.logical || m.Total() != tt.total {
+			t.Errorf("%s: %s with %d logical processors and %d bytes, want %s, %d and %d", tt.tree,
+				id.Vendor(), p.LogicalProcessorCount(), m.Total(), tt.vendor, tt.logical, tt.total)
+		}
+	}
+}
@@ -0,0 +1,139 @@
+/*
+ * Copyright 2016-2024 The OSHI Project Contributors
+ * SPDX-License-Identifier: MIT
+ */
+
+package linux
+
+import (
+	"fmt"
+	"goshi/sysinfo/hardware"
+	"goshi/util"
+	"goshi/util/smbios"
+	"os"
+	"strings"
+)
+
+type LinuxVirtualMemory struct {
+	memInfo func() map[string]int64
+	vmStat  func() map[string]int64
+}
+
+func (l LinuxVirtualMemory) SwapTotal() int64 {
+	return l.memInfo()["SwapTotal"]
+}
+
+func (l LinuxVirtualMemory) SwapUsed() int64 {
+	info := l.memInfo()
+	return info["SwapTotal"] - info["SwapFree"]
+}
+
+func (l LinuxVirtualMemory) VirtualMax() int64 {
+	return l.memInfo()["CommitLimit"]
+}
+
+func (l LinuxVirtualMemory) VirtualInUse() int64 {
+	return l.memInfo()["Committed_AS"]
+}
+
+func (l LinuxVirtualMemory) SwapPagesIn() int64 {
+	return l.vmStat()["pswpin"]
+}
+
+func (l LinuxVirtualMemory) SwapPagesOut() int64 {
+	return l.vmStat()["pswpout"]
+}
+
+type LinuxGlobalMemory struct {
+	memInfo       func() map[string]int64
+	smbiosTable   func() (smbios.Table, error)
+	virtualMemory hardware.VirtualMemory
+}
+
+func (l LinuxGlobalMemory) Total() int64 {
+	return l.memInfo()["MemTotal"]
+}
+
+func (l LinuxGlobalMemory) Available() int64 {
+	info := l.memInfo()
+	if avail, exists := info["MemAvailable"]; exists {
+		return avail
+	}
+	// kernels older than 3.14 do not report MemAvailable
+	return info["MemFree"] + info["Buffers"] + info["Cached"]
+}
+
+func (l LinuxGlobalMemory) PageSize() int64 {
+	return int64(os.Getpagesize())
+}
+
+func (l LinuxGlobalMemory) VirtualMemory() hardware.VirtualMemory {
+	return l.virtualMemory
+}
+
+func (l LinuxGlobalMemory) PhysicalMemory() ([]hardware.PhysicalMemory, error) {
+	table, err := l.smbiosTable()
+	if err != nil {
+		return nil, err
+	}
+	memories := make([]hardware.PhysicalMemory, 0)
+	for _, device := range table.MemoryDevices() {
+		if device.Size <= 0 {
+			// an empty slot, or a module whose size is not known
+			continue
+		}
+		bankLabel := util.StringValueOrDefault(device.BankLocator, util.Unknown)
+		if len(device.DeviceLocator) != 0 {
+			bankLabel = fmt.Sprintf("%s/%s", bankLabel, device.DeviceLocator)
+		}
+		pmem := hardware.NewPhysicalMemory(
+			bankLabel,
+			util.StringValueOrDefault(device.Manufacturer, util.Unknown),
+			hardware.SMBiosMemoryType(uint32(device.Type)),
+			util.StringValueOrDefault(device.PartNumber, util.Unknown),
+			util.StringValueOrDefault(device.SerialNumber, util.Unknown),
+			device.Size,
+			device.Speed*1_000_000,
+		)
+		memories = append(memories, pmem)
+	}
+	return memories, nil
+}
+
+// readMemInfo returns the values of /proc/meminfo in bytes
+func readMemInfo() map[string]int64 {
+	res := make(map[string]int64)
+	for key, value := range util.ReadKeyValues(rootPath(procMemInfo), ":") {
+		fields := strings.Fields(value)
+		if len(fields) == 0 {
+			continue
+		}
+		val := util.ParseInt64OrDefault(fields[0], 0)
+		if len(fields) > 1 && fields[1] == "kB" {
+			val *= 1024
+		}
+		res[key] = val
+	}
+	return res
+}
+
+func readVmStat() map[string]int64 {
+	res := make(map[string]int64)
+	for key, value := range util.ReadKeyValues(rootPath(procVmStat), " ") {
+		res[key] = util.ParseInt64OrDefault(value, 0)
+	}
+	return res
+}
+
+func GlobalMemory() hardware.GlobalMemory {
+	ttl := util.DefaultExpiration()
+	memInfo := util.Memoize(readMemInfo, ttl)
+	return LinuxGlobalMemory{
+		memInfo:     memInfo,
+		smbiosTable: util.MemoizeWithError(readSmbiosTable, -1),
+		virtualMemory: LinuxVirtualMemory{
+			memInfo: memInfo,
+			vmStat:  util.Memoize(readVmStat, ttl),
+		},
+	}
+}
@@ -0,0 +1,78 @@
+/*
+ * Copyright 2016-2024 The OSHI Project Contributors
+ * SPDX-License-Identifier: MIT
+ */
+
+package linux
+
+import (
+	"goshi/sysinfo/hardware"
+	"reflect"
+	"testing"
+)
+
+func TestGlobalMemory(t *testing.T) {
+	tests := []struct {
+		tree   string
+		memory []int64
+		swap   []int64
+	}{
+		{
+			tree:   "x86-hybrid",
+			memory: []int64{16131016 << 10, 9876543 << 10},
+			swap:   []int64{8388604 << 10, 388604 << 10, 16453112 << 10, 12345678 << 10, 1200, 3400},
+		},
+		{
+			tree: "arm64-tri-cluster",
+			// no MemAvailable, free memory plus buffers and page cache
+			memory: []int64{3884836 << 10, (204800 + 102400 + 1048576) << 10},
+			swap:   []int64{0, 0, 1942416 << 10, 2500000 << 10, 0, 0},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.tree, func(t *testing.T) {
+			setFixtureRoot(t, tt.tree)
+			m := GlobalMemory()
+			if got := []int64{m.Total(), m.Available()}; !reflect.DeepEqual(got, tt.memory) {
+				t.Errorf("total and available = %v, want %v", got, tt.memory)
+			}
+			v := m.VirtualMemory()
+			got := []int64{v.SwapTotal(), v.SwapUsed(), v.VirtualMax(), v.VirtualInUse(), v.SwapPagesIn(), v.SwapPagesOut()}
+			if !reflect.DeepEqual(got, tt.swap) {
+				t.Errorf("virtual memory = %v, want %v", got, tt.swap)
+			}
+		})
+	}
+}
+
+func TestPhysicalMemory(t *testing.T) {
+	tests := []struct {
+		tree    string
+		want    []hardware.PhysicalMemory
+		wantErr bool
+	}{
+		{
+			tree: "x86-hybrid",
+			// the slot of unknown size and the empty slot are skipped
+			want: []hardware.PhysicalMemory{
+				hardware.NewPhysicalMemory("BANK 0/Controller0-ChannelA-DIMM0", "Samsung", "DDR5", "M425R2GA3BB0-CQKOD", "12345678", 16<<30, 4_800_000_000),
+				// the extended size in megabytes
+				hardware.NewPhysicalMemory("BANK 2/Controller1-ChannelA-DIMM0", "Micron Technology", "DDR5", "MTC16C2085S1SC48BA1", "E1F2A3B4", 32<<30, 4_800_000_000),
+			},
+		},
+		// arm boards have no dmi tables
+		{tree: "arm64-tri-cluster", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.tree, func(t *testing.T) {
+			setFixtureRoot(t, tt.tree)
+			got, err := GlobalMemory().PhysicalMemory()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, want an error: %t", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("physical memory = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
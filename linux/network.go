@@ -0,0 +1,265 @@
+/*
+ * Copyright 2016-2024 The OSHI Project Contributors
+ * SPDX-License-Identifier: MIT
+ */
+
+package linux
+
+import (
+	"errors"
+	"fmt"
+	"goshi/sysinfo/hardware"
+	"goshi/util"
+	"io/fs"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// ARPHRD_LOOPBACK of if_arp.h, the type of the loopback interface
+	arphrdLoopback = 772
+)
+
+var (
+	operStates = map[string]hardware.IfOperStatus{
+		"up":             hardware.IfUp,
+		"down":           hardware.IfDown,
+		"testing":        hardware.IfTesting,
+		"unknown":        hardware.IfUnknown,
+		"dormant":        hardware.IfDormant,
+		"notpresent":     hardware.IfNotPresent,
+		"lowerlayerdown": hardware.IfLowerLayerDown,
+	}
+)
+
+type LinuxNetworkIF struct {
+	name, displayName, macAddr                     string
+	index, mtu                                     int
+	ipv4Addr, ipv6Addr                             []string
+	subnetMasks, prefixLengths                     []int
+	speed                                          int64
+	ifOperStatus                                   hardware.IfOperStatus
+	virtual                                        bool
+	bytesRecv, bytesSent, packetsRecv, packetsSent int64
+	inErrors, outErrors, inDrops, collisions       int64
+	timeStamp                                      int64
+}
+
+func (l *LinuxNetworkIF) Name() string {
+	return l.name
+}
+
+func (l *LinuxNetworkIF) DisplayName() string {
+	return l.displayName
+}
+
+func (l *LinuxNetworkIF) Index() int {
+	return l.index
+}
+
+func (l *LinuxNetworkIF) MTU() int {
+	return l.mtu
+}
+
+func (l *LinuxNetworkIF) MacAddr() string {
+	return l.macAddr
+}
+
+func (l *LinuxNetworkIF) IPv4Addr() []string {
+	return l.ipv4Addr
+}
+
+func (l *LinuxNetworkIF) SubnetMasks() []int {
+	return l.subnetMasks
+}
+
+func (l *LinuxNetworkIF) IPv6Addr() []string {
+	return l.ipv6Addr
+}
+
+func (l *LinuxNetworkIF) PrefixLengths() []int {
+	return l.prefixLengths
+}
+
+func (l *LinuxNetworkIF) Speed() int64 {
+	return l.speed
+}
+
+func (l *LinuxNetworkIF) IfOperStatus() hardware.IfOperStatus {
+	return l.ifOperStatus
+}
+
+func (l *LinuxNetworkIF) Virtual() bool {
+	return l.virtual
+}
+
+func (l *LinuxNetworkIF) BytesRecv() int64 {
+	return l.bytesRecv
+}
+
+func (l *LinuxNetworkIF) BytesSent() int64 {
+	return l.bytesSent
+}
+
+func (l *LinuxNetworkIF) PacketsRecv() int64 {
+	return l.packetsRecv
+}
+
+func (l *LinuxNetworkIF) PacketsSent() int64 {
+	return l.packetsSent
+}
+
+func (l *LinuxNetworkIF) InErrors() int64 {
+	return l.inErrors
+}
+
+func (l *LinuxNetworkIF) OutErrors() int64 {
+	return l.outErrors
+}
+
+func (l *LinuxNetworkIF) InDrops() int64 {
+	return l.inDrops
+}
+
+func (l *LinuxNetworkIF) Collisions() int64 {
+	return l.collisions
+}
+
+func (l *LinuxNetworkIF) TimeStamp() int64 {
+	return l.timeStamp
+}
+
+func (l *LinuxNetworkIF) UpdateAttributes() bool {
+	dir := filepath.Join(rootPath(sysClassNet), l.name)
+	if _, err := os.Stat(dir); err != nil {
+		return false
+	}
+	l.update(dir, readNetDev()[l.name])
+	return true
+}
+
+// update reads the speed, status and the counters of a /proc/net/dev line, fields after the interface name
+func (l *LinuxNetworkIF) update(dir string, stats []int64) {
+	// speed is in Mbit/s, reading it fails while the link is down
+	l.speed = max(util.ReadInt64OrDefault(filepath.Join(dir, "speed"), 0), 0) * 1_000_000
+	l.ifOperStatus = hardware.IfUnknown
+	if status, exists := operStates[util.ReadString(filepath.Join(dir, "operstate"))]; exists {
+		l.ifOperStatus = status
+	}
+	if len(stats) == 16 {
+		l.bytesRecv = stats[0]
+		l.packetsRecv = stats[1]
+		l.inErrors = stats[2]
+		l.inDrops = stats[3]
+		l.bytesSent = stats[8]
+		l.packetsSent = stats[9]
+		l.outErrors = stats[10]
+		l.collisions = stats[13]
+	}
+	l.timeStamp = time.Now().UnixMilli()
+}
+
+// readNetDev maps interface names to the 16 receive and transmit counters of /proc/net/dev
+func readNetDev() map[string][]int64 {
+	stats := make(map[string][]int64)
+	for _, line := range util.ReadLines(rootPath(procNetDev)) {
+		name, counters, found := strings.Cut(line, ":")
+		if !found {
+			continue
+		}
+		fields := strings.Fields(counters)
+		if len(fields) != 16 {
+			continue
+		}
+		values := make([]int64, len(fields))
+		for i, field := range fields {
+			values[i] = util.ParseInt64OrDefault(field, 0)
+		}
+		stats[strings.TrimSpace(name)] = values
+	}
+	return stats
+}
+
+// setAddresses splits the addresses of the interface by family, they are only known for the live system
+func (l *LinuxNetworkIF) setAddresses() {
+	l.ipv4Addr, l.subnetMasks = make([]string, 0), make([]int, 0)
+	l.ipv6Addr, l.prefixLengths = make([]string, 0), make([]int, 0)
+	if Root() != "/" {
+		return
+	}
+	iface, err := net.InterfaceByName(l.name)
+	if err != nil {
+		return
+	}
+	addrs, err := iface.Addrs()
+	if err != nil {
+		return
+	}
+	for _, addr := range addrs {
+		ipNet, ok := addr.(*net.IPNet)
+		if !ok {
+			continue
+		}
+		ones, _ := ipNet.Mask.Size()
+		if ip := ipNet.IP.To4(); ip != nil {
+			l.ipv4Addr = append(l.ipv4Addr, ip.String())
+			l.subnetMasks = append(l.subnetMasks, ones)
+		} else {
+			l.ipv6Addr = append(l.ipv6Addr, ipNet.IP.String())
+			l.prefixLengths = append(l.prefixLengths, ones)
+		}
+	}
+}
+
+func networkDisplayName(name string, index int, dir string) string {
+	if alias := util.ReadString(filepath.Join(dir, "ifalias")); len(alias) != 0 {
+		return alias
+	}
+	udev := udevProperties(fmt.Sprintf("n%d", index))
+	if model, exists := udev["ID_MODEL_FROM_DATABASE"]; exists {
+		return model
+	}
+	return name
+}
+
+// NetworkIFs lists the network interfaces, the loopback interface only if includeLocal is set.
+func NetworkIFs(includeLocal bool) ([]hardware.NetworkIF, error) {
+	netDir := rootPath(sysClassNet)
+	entries, err := os.ReadDir(netDir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return make([]hardware.NetworkIF, 0), nil
+	} else if err != nil {
+		return nil, fmt.Errorf("net: failed to read %s: %w", netDir, err)
+	}
+	stats := readNetDev()
+	ifs := make([]hardware.NetworkIF, 0)
+	for _, entry := range entries {
+		name := entry.Name()
+		dir := filepath.Join(netDir, name)
+		if !includeLocal && util.ReadIntOrDefault(filepath.Join(dir, "type"), 0) == arphrdLoopback {
+			continue
+		}
+		index := util.ReadIntOrDefault(filepath.Join(dir, "ifindex"), 0)
+		// interfaces without a device, such as bridges and tunnels, are under /sys/devices/virtual
+		_, err := os.Stat(filepath.Join(dir, "device"))
+		netIF := &LinuxNetworkIF{
+			name:        name,
+			displayName: networkDisplayName(name, index, dir),
+			index:       index,
+			mtu:         util.ReadIntOrDefault(filepath.Join(dir, "mtu"), 0),
+			macAddr:     util.StringValueOrDefault(util.ReadString(filepath.Join(dir, "address")), util.Unknown),
+			virtual:     err != nil,
+		}
+		netIF.setAddresses()
+		netIF.update(dir, stats[name])
+		ifs = append(ifs, netIF)
+	}
+	sort.Slice(ifs, func(i, j int) bool {
+		return ifs[i].Index() < ifs[j].Index()
+	})
+	return ifs, nil
+}
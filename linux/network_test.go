@@ -0,0 +1,109 @@
+/*
+ * Copyright 2016-2024 The OSHI Project Contributors
+ * SPDX-License-Identifier: MIT
+ */
+
+package linux
+
+import (
+	"goshi/sysinfo/hardware"
+	"goshi/util"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestNetworkIFs(t *testing.T) {
+	type netIF struct {
+		name, displayName, macAddr string
+		index, mtu                 int
+		speed                      int64
+		status                     hardware.IfOperStatus
+		virtual                    bool
+		bytesRecv, packetsRecv     int64
+		bytesSent, packetsSent     int64
+		inErrors, outErrors        int64
+		inDrops, collisions        int64
+	}
+	tests := []struct {
+		tree string
+		ifs  []netIF
+	}{
+		{
+			tree: "x86-hybrid",
+			ifs: []netIF{
+				{"lo", "lo", "00:00:00:00:00:00", 1, 65536, 0, hardware.IfUnknown, true, 81234, 900, 81234, 900, 0, 0, 0, 0},
+				// the name of the hardware database, and a speed of -1 without a cable
+				{"enp2s0", "RTL8111/8168/8411 PCI Express Gigabit Ethernet Controller", "a4:bb:6d:12:34:56", 2, 1500, 0, hardware.IfDown, false, 0, 0, 0, 0, 0, 0, 0, 0},
+				{"wlp0s20f3", "Alder Lake-P PCH CNVi WiFi", "f0:9e:4a:ab:cd:ef", 3, 1500, 0, hardware.IfUp, false, 987654321, 812345, 123456789, 234567, 3, 1, 12, 2},
+				// the alias comes first
+				{"docker0", "container bridge", "02:42:7c:11:22:33", 4, 1500, 0, hardware.IfDown, true, 0, 0, 4096, 40, 0, 0, 0, 0},
+			},
+		},
+		{
+			tree: "arm64-tri-cluster",
+			ifs: []netIF{
+				{"lo", "lo", "00:00:00:00:00:00", 1, 65536, 0, hardware.IfUnknown, true, 5000, 50, 5000, 50, 0, 0, 0, 0},
+				// raw ip has no link layer address
+				{"rmnet_data0", "rmnet_data0", util.Unknown, 9, 1500, 0, hardware.IfUp, true, 44000000, 40000, 3000000, 20000, 0, 0, 7, 0},
+				{"wlan0", "wlan0", "62:1f:aa:00:11:22", 12, 1500, 0, hardware.IfDormant, false, 0, 0, 0, 0, 0, 0, 0, 0},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.tree, func(t *testing.T) {
+			setFixtureRoot(t, tt.tree)
+			ifs, err := NetworkIFs(true)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(ifs) != len(tt.ifs) {
+				t.Fatalf("got %d interfaces, want %d", len(ifs), len(tt.ifs))
+			}
+			for i, n := range ifs {
+				got := netIF{
+					n.Name(), n.DisplayName(), n.MacAddr(), n.Index(), n.MTU(), n.Speed(), n.IfOperStatus(), n.Virtual(),
+					n.BytesRecv(), n.PacketsRecv(), n.BytesSent(), n.PacketsSent(),
+					n.InErrors(), n.OutErrors(), n.InDrops(), n.Collisions(),
+				}
+				if got != tt.ifs[i] {
+					t.Errorf("interface = %+v, want %+v", got, tt.ifs[i])
+				}
+				// addresses are only known for the live system
+				if len(n.IPv4Addr()) != 0 || len(n.IPv6Addr()) != 0 {
+					t.Errorf("%s has addresses %v %v", n.Name(), n.IPv4Addr(), n.IPv6Addr())
+				}
+				if !n.UpdateAttributes() || n.TimeStamp() <= 0 {
+					t.Errorf("%s is gone after an update", n.Name())
+				}
+			}
+
+			// the loopback interface is skipped by its type
+			ifs, err = NetworkIFs(false)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(ifs) != len(tt.ifs)-1 || ifs[0].Name() == "lo" {
+				t.Errorf("got %d interfaces without the loopback, starting with %s", len(ifs), ifs[0].Name())
+			}
+		})
+	}
+}
+
+func TestNetworkSpeed(t *testing.T) {
+	setFixtureRoot(t, "x86-hybrid")
+	dir := rootPath(sysClassNet + "/enp2s0")
+	// the link came up at gigabit speed
+	for name, value := range map[string]string{"speed": "1000\n", "operstate": "up\n"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(value), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	n := &LinuxNetworkIF{name: "enp2s0"}
+	if !n.UpdateAttributes() || n.Speed() != 1_000_000_000 || n.IfOperStatus() != hardware.IfUp {
+		t.Errorf("speed = %d, status = %v after the link came up", n.Speed(), n.IfOperStatus())
+	}
+	if n = (&LinuxNetworkIF{name: "eth9"}); n.UpdateAttributes() {
+		t.Errorf("eth9 does not exist")
+	}
+}
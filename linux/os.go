@@ -0,0 +1,62 @@
+/*
+ * Copyright 2016-2024 The OSHI Project Contributors
+ * SPDX-License-Identifier: MIT
+ */
+
+package linux
+
+import (
+	"goshi/sysinfo/software"
+	"goshi/util"
+	"strings"
+)
+
+type LinuxOperatingSystem struct {
+	family      string
+	versionInfo software.OSVersionInfo
+	bitness     int
+}
+
+func (l LinuxOperatingSystem) Family() string {
+	return l.family
+}
+
+func (l LinuxOperatingSystem) Manufacturer() string {
+	return "GNU/Linux"
+}
+
+func (l LinuxOperatingSystem) VersionInfo() software.OSVersionInfo {
+	return l.versionInfo
+}
+
+func (l LinuxOperatingSystem) Bitness() int {
+	return l.bitness
+}
+
+func readOsRelease() map[string]string {
+	release := util.ReadKeyValues(rootPath(etcOsRelease), "=")
+	if len(release) == 0 {
+		release = util.ReadKeyValues(rootPath(usrLibOsRelease), "=")
+	}
+	for k, v := range release {
+		release[k] = strings.Trim(v, `"'`)
+	}
+	return release
+}
+
+func OperatingSystem() software.OperatingSystem {
+	release := readOsRelease()
+	bitness := util.Bits
+	if bitness < 64 && strings.Contains(util.ReadString(rootPath(procArch)), "64") {
+		bitness = 64
+	}
+	return LinuxOperatingSystem{
+		family: util.StringValueOrDefault(release["NAME"], "Linux"),
+		versionInfo: software.NewOSVersionInfo(
+			util.StringValueOrDefault(release["VERSION_ID"], util.Unknown),
+			release["VERSION_CODENAME"],
+			util.ReadString(rootPath(procRelease)),
+		),
+		bitness: bitness,
+	}
+}
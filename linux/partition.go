@@ -0,0 +1,136 @@
+/*
+ * Copyright 2016-2024 The OSHI Project Contributors
+ * SPDX-License-Identifier: MIT
+ */
+
+package linux
+
+import (
+	"fmt"
+	"goshi/sysinfo/hardware"
+	"goshi/util"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type mount struct {
+	mountPoint, fsType string
+	// subtree is set for bind mounts and subvolumes, which mount a directory below the file system root
+	subtree bool
+}
+
+type mountInfo struct {
+	byDevice map[string]mount
+	bySource map[string]mount
+}
+
+// unescapeMountInfo decodes the octal escapes (\040 for a space) of /proc/self/mountinfo fields
+func unescapeMountInfo(s string) string {
+	if !strings.Contains(s, `\`) {
+		return s
+	}
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+4 <= len(s) {
+			if c, err := strconv.ParseUint(s[i+1:i+4], 8, 8); err == nil {
+				b.WriteByte(byte(c))
+				i += 3
+				continue
+			}
+		}
+		b.WriteByte(s[i])
+	}
+	return b.String()
+}
+
+// readMountInfo indexes the mount point of every device by its "major:minor" number and by its
+// source, the device number of btrfs and other virtual block devices does not match the disk.
+// Mounts of the root of a file system win over bind mounts and subvolumes, which are only kept
+// when the device has no other mount, as for a btrfs subvolume "/@" mounted on /.
+func readMountInfo() mountInfo {
+	info := mountInfo{
+		byDevice: make(map[string]mount),
+		bySource: make(map[string]mount),
+	}
+	add := func(index map[string]mount, key string, m mount) {
+		if prev, exists := index[key]; !exists || prev.subtree && !m.subtree {
+			index[key] = m
+		}
+	}
+	for _, line := range util.ReadLines(rootPath(procMountInfo)) {
+		// 36 35 98:0 /mnt1 /mnt2 rw,noatime master:1 - ext3 /dev/root rw,errors=continue
+		fields := strings.Fields(line)
+		sep := -1
+		for i, field := range fields {
+			if field == "-" {
+				sep = i
+				break
+			}
+		}
+		if sep < 5 || sep+2 >= len(fields) {
+			continue
+		}
+		m := mount{mountPoint: unescapeMountInfo(fields[4]), fsType: fields[sep+1], subtree: fields[3] != "/"}
+		add(info.byDevice, fields[2], m)
+		if source := unescapeMountInfo(fields[sep+2]); strings.HasPrefix(source, "/dev/") {
+			add(info.bySource, source, m)
+		}
+	}
+	return info
+}
+
+// diskPartitions lists the partitions of /proc/partitions by the kernel name of their disk
+func diskPartitions() map[string][]hardware.HWPartition {
+	mounts := readMountInfo()
+	partitions := make(map[string][]hardware.HWPartition)
+	for _, line := range util.ReadLines(rootPath(procPartition)) {
+		// major minor #blocks name
+		fields := strings.Fields(line)
+		if len(fields) != 4 {
+			continue
+		}
+		major, err := strconv.Atoi(fields[0])
+		if err != nil {
+			continue
+		}
+		minor := int(util.ParseInt64OrDefault(fields[1], 0))
+		name := fields[3]
+		class := filepath.Join(rootPath(sysClassBlk), name)
+		if _, err := os.Stat(filepath.Join(class, "partition")); err != nil {
+			continue
+		}
+		resolved, err := filepath.EvalSymlinks(class)
+		if err != nil {
+			continue
+		}
+		disk := filepath.Base(filepath.Dir(resolved))
+		devNumber := fmt.Sprintf("%d:%d", major, minor)
+		identification := "/dev/" + name
+		udev := udevProperties(udevBlockDevice(major, minor))
+		m, exists := mounts.byDevice[devNumber]
+		if !exists {
+			m = mounts.bySource[identification]
+		}
+		typ := util.StringValueOrDefault(udev["ID_FS_TYPE"], m.fsType)
+		uuid := util.StringValueOrDefault(udev["ID_PART_ENTRY_UUID"], udev["ID_FS_UUID"])
+		partitions[disk] = append(partitions[disk], hardware.NewHWPartition(
+			identification,
+			name,
+			util.StringValueOrDefault(typ, util.Unknown),
+			util.StringValueOrDefault(uuid, util.Unknown),
+			util.ParseInt64OrDefault(fields[2], 0)*1024,
+			major,
+			minor,
+			m.mountPoint,
+		))
+	}
+	for _, parts := range partitions {
+		sort.Slice(parts, func(i, j int) bool {
+			return parts[i].Minor() < parts[j].Minor()
+		})
+	}
+	return partitions
+}
@@ -0,0 +1,70 @@
+/*
+ * Copyright 2016-2024 The OSHI Project Contributors
+ * SPDX-License-Identifier: MIT
+ */
+
+package linux
+
+import (
+	"goshi/util"
+	"path/filepath"
+)
+
+const (
+	etcOsRelease    = "/etc/os-release"
+	usrLibOsRelease = "/usr/lib/os-release"
+
+	procCpuInfo   = "/proc/cpuinfo"
+	procLoadAvg   = "/proc/loadavg"
+	procDiskStats = "/proc/diskstats"
+	procPartition = "/proc/partitions"
+	procStat      = "/proc/stat"
+	procMemInfo   = "/proc/meminfo"
+	procModel     = "/proc/device-tree/model"
+	procSerial    = "/proc/device-tree/serial-number"
+	procVmStat    = "/proc/vmstat"
+	procArch      = "/proc/sys/kernel/arch"
+	procRelease   = "/proc/sys/kernel/osrelease"
+	procAuxv      = "/proc/self/auxv"
+	procAsound    = "/proc/asound"
+	procMountInfo = "/proc/self/mountinfo"
+	procNetDev    = "/proc/net/dev"
+
+	sysBlock    = "/sys/block"
+	sysBusPci   = "/sys/bus/pci/devices"
+	sysBusUsb   = "/sys/bus/usb/devices"
+	sysClassBlk = "/sys/class/block"
+	sysClassDrm = "/sys/class/drm"
+	sysClassNet = "/sys/class/net"
+	sysClassSnd = "/sys/class/sound"
+	sysPower    = "/sys/class/power_supply"
+	sysHwmon    = "/sys/class/hwmon"
+	sysThermal  = "/sys/class/thermal"
+	sysCpu      = "/sys/devices/system/cpu"
+	sysCpuFreq  = "/sys/devices/system/cpu/cpufreq"
+	sysCpuCore  = "/sys/devices/cpu_core/cpus"
+	sysCpuAtom  = "/sys/devices/cpu_atom/cpus"
+	sysPState   = "/sys/devices/system/cpu/intel_pstate"
+	sysDmiId    = "/sys/class/dmi/id"
+	sysDmiTable = "/sys/firmware/dmi/tables/DMI"
+	sysDmiEntry = "/sys/firmware/dmi/tables/smbios_entry_point"
+	sysEfi      = "/sys/firmware/efi"
+	sysModule   = "/sys/module"
+	sysNode     = "/sys/devices/system/node"
+
+	runUdevData = "/run/udev/data"
+)
+
+// SetRoot makes every linux reader resolve its paths under root, so that a captured
+// or mounted filesystem tree is read instead of the live system. An empty root restores "/".
+func SetRoot(root string) {
+	util.SetConfig(util.ConfigRootPath, root)
+}
+
+func Root() string {
+	return util.ConfigString(util.ConfigRootPath, "/")
+}
+
+func rootPath(path string) string {
+	return filepath.Join(Root(), path)
+}
@@ -0,0 +1,79 @@
+/*
+ * Copyright 2016-2024 The OSHI Project Contributors
+ * SPDX-License-Identifier: MIT
+ */
+
+package linux
+
+import (
+	"errors"
+	"fmt"
+	"goshi/internal/ids"
+	"goshi/sysinfo/hardware"
+	"goshi/util"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+)
+
+// linkName returns the last element of the target of a sysfs link, empty if there is no link
+func linkName(path string) string {
+	target, err := os.Readlink(path)
+	if err != nil {
+		return ""
+	}
+	return filepath.Base(target)
+}
+
+func pciDevice(dir string) hardware.PciDevice {
+	read := func(name string) string {
+		return parsePCIID(util.ReadString(filepath.Join(dir, name)))
+	}
+	classId, vendorId, deviceId := read("class"), read("vendor"), read("device")
+	subVendorId, subDeviceId := read("subsystem_vendor"), read("subsystem_device")
+	subsystemName := ids.PciSubsystem(vendorId, deviceId, subVendorId, subDeviceId)
+	if len(subsystemName) == 0 {
+		subsystemName = fmt.Sprintf("%s:%s", subVendorId, subDeviceId)
+	}
+	iommuGroup, err := strconv.Atoi(linkName(filepath.Join(dir, "iommu_group")))
+	if err != nil {
+		iommuGroup = -1
+	}
+	return hardware.NewPciDevice(
+		filepath.Base(dir),
+		classId,
+		util.StringValueOrDefault(ids.PciClass(classId), classId),
+		vendorId,
+		util.StringValueOrDefault(ids.PciVendor(vendorId), vendorId),
+		deviceId,
+		util.StringValueOrDefault(ids.PciDevice(vendorId, deviceId), deviceId),
+		subVendorId,
+		subDeviceId,
+		subsystemName,
+		read("revision"),
+		linkName(filepath.Join(dir, "driver")),
+		iommuGroup,
+		util.ReadIntOrDefault(filepath.Join(dir, "numa_node"), -1),
+	)
+}
+
+func PciDevices() ([]hardware.PciDevice, error) {
+	pciDir := rootPath(sysBusPci)
+	entries, err := os.ReadDir(pciDir)
+	if errors.Is(err, fs.ErrNotExist) {
+		// no pci bus, as on many arm boards
+		return make([]hardware.PciDevice, 0), nil
+	} else if err != nil {
+		return nil, fmt.Errorf("pci: failed to read %s: %w", pciDir, err)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Name() < entries[j].Name()
+	})
+	devices := make([]hardware.PciDevice, 0, len(entries))
+	for _, entry := range entries {
+		devices = append(devices, pciDevice(filepath.Join(pciDir, entry.Name())))
+	}
+	return devices, nil
+}
@@ -0,0 +1,50 @@
+/*
+ * Copyright 2016-2024 The OSHI Project Contributors
+ * SPDX-License-Identifier: MIT
+ */
+
+package linux
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPciDevices(t *testing.T) {
+	type device struct {
+		address, classId, className, vendor, name, subsystemName, revision, driver string
+		iommuGroup, numaNode                                                       int
+	}
+	setFixtureRoot(t, "x86-hybrid")
+	want := []device{
+		// the most specific name of the class code: the programming interface of a bridge
+		{"0000:00:01.0", "0x060400", "Normal decode", "Intel Corporation", "12th Gen Core Processor PCI Express x16 Controller #1", "0x17aa:0x22e7", "0x02", "pcieport", 1, -1},
+		{"0000:00:02.0", "0x030000", "VGA controller", "Intel Corporation", "Alder Lake-P GT2 [Iris Xe Graphics]", "0x17aa:0x22e7", "0x0c", "i915", 0, -1},
+		{"0000:00:14.0", "0x0c0330", "XHCI", "Intel Corporation", "Alder Lake PCH USB 3.2 xHCI Host Controller", "0x17aa:0x22e7", "0x01", "xhci_hcd", 5, -1},
+		// a subsystem known to pci.ids, the subclass names the unknown programming interface
+		{"0000:00:14.3", "0x028000", "Network controller", "Intel Corporation", "Alder Lake-P PCH CNVi WiFi", "Dual Band Wi-Fi 6E(802.11ax) AX211 160MHz 2x2 [Garfield Peak]", "0x01", "iwlwifi", 6, -1},
+		{"0000:00:1c.0", "0x060400", "Normal decode", "Intel Corporation", "Alder Lake PCI Express Root Port #9", "0x17aa:0x22e7", "0x01", "pcieport", 7, -1},
+		{"0000:00:1f.3", "0x040380", "Audio device", "Intel Corporation", "Alder Lake PCH-P High Definition Audio Controller", "0x17aa:0x22e7", "0x01", "snd_hda_intel", 8, -1},
+		// devices behind bridges are listed by address
+		{"0000:01:00.0", "0x030200", "3D controller", "NVIDIA Corporation", "GA107M [GeForce RTX 3050 Mobile]", "0x17aa:0x22e7", "0xa1", "nvidia", 9, -1},
+		// no iommu group
+		{"0000:02:00.0", "0x020000", "Ethernet controller", "Realtek Semiconductor Co., Ltd.", "RTL8111/8168/8211/8411 PCI Express Gigabit Ethernet Controller", "0x17aa:0x22e7", "0x15", "r8169", -1, -1},
+	}
+	devices, err := PciDevices()
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := make([]device, 0)
+	for _, d := range devices {
+		got = append(got, device{d.Address(), d.ClassId(), d.ClassName(), d.Vendor(), d.Name(), d.SubsystemName(), d.Revision(), d.Driver(), d.IommuGroup(), d.NumaNode()})
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("devices = %+v, want %+v", got, want)
+	}
+
+	// the arm board has no pci bus
+	setFixtureRoot(t, "arm64-tri-cluster")
+	if devices, err = PciDevices(); err != nil || len(devices) != 0 {
+		t.Errorf("got %d devices and %v, want none", len(devices), err)
+	}
+}
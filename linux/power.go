@@ -0,0 +1,231 @@
+/*
+ * Copyright 2016-2024 The OSHI Project Contributors
+ * SPDX-License-Identifier: MIT
+ */
+
+package linux
+
+import (
+	"goshi/sysinfo/hardware"
+	"goshi/util"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+type LinuxPowerSource struct {
+	dir, name, deviceName, chemistry, manufacturer, serialNumber string
+	remainingCapacityPercent, timeRemainingEstimated             float64
+	powerUsageRate, voltage, amperage                            float64
+	powerOnLine, charging, discharging                           bool
+	capacityUnits                                                hardware.CapacityUnits
+	currentCapacity, maxCapacity, designCapacity                 int64
+	cycleCount                                                   int
+}
+
+func (l *LinuxPowerSource) Name() string {
+	return l.name
+}
+
+func (l *LinuxPowerSource) DeviceName() string {
+	return l.deviceName
+}
+
+func (l *LinuxPowerSource) RemainingCapacityPercent() float64 {
+	return l.remainingCapacityPercent
+}
+
+func (l *LinuxPowerSource) TimeRemainingEstimated() float64 {
+	return l.timeRemainingEstimated
+}
+
+func (l *LinuxPowerSource) PowerUsageRate() float64 {
+	return l.powerUsageRate
+}
+
+func (l *LinuxPowerSource) Voltage() float64 {
+	return l.voltage
+}
+
+func (l *LinuxPowerSource) Amperage() float64 {
+	return l.amperage
+}
+
+func (l *LinuxPowerSource) PowerOnLine() bool {
+	return l.powerOnLine
+}
+
+func (l *LinuxPowerSource) Charging() bool {
+	return l.charging
+}
+
+func (l *LinuxPowerSource) Discharging() bool {
+	return l.discharging
+}
+
+func (l *LinuxPowerSource) CapacityUnits() hardware.CapacityUnits {
+	return l.capacityUnits
+}
+
+func (l *LinuxPowerSource) CurrentCapacity() int64 {
+	return l.currentCapacity
+}
+
+func (l *LinuxPowerSource) MaxCapacity() int64 {
+	return l.maxCapacity
+}
+
+func (l *LinuxPowerSource) DesignCapacity() int64 {
+	return l.designCapacity
+}
+
+func (l *LinuxPowerSource) CycleCount() int {
+	return l.cycleCount
+}
+
+func (l *LinuxPowerSource) Chemistry() string {
+	return l.chemistry
+}
+
+func (l *LinuxPowerSource) Manufacturer() string {
+	return l.manufacturer
+}
+
+func (l *LinuxPowerSource) SerialNumber() string {
+	return l.serialNumber
+}
+
+func (l *LinuxPowerSource) UpdateAttributes() bool {
+	uevent := readPowerSupply(l.dir)
+	if len(uevent) == 0 {
+		return false
+	}
+	l.update(uevent, externalPowerOnLine())
+	return true
+}
+
+// readPowerSupply returns the uevent properties of a power supply without their POWER_SUPPLY_ prefix
+func readPowerSupply(dir string) map[string]string {
+	props := make(map[string]string)
+	for key, value := range util.ReadKeyValues(filepath.Join(dir, "uevent"), "=") {
+		if key, found := strings.CutPrefix(key, "POWER_SUPPLY_"); found {
+			props[key] = value
+		}
+	}
+	return props
+}
+
+// externalPowerOnLine reports whether a mains adapter or usb power supply is online
+func externalPowerOnLine() bool {
+	dirs, _ := filepath.Glob(filepath.Join(rootPath(sysPower), "*"))
+	for _, dir := range dirs {
+		props := readPowerSupply(dir)
+		// USB, USB_C, USB_PD and the other usb charger types
+		typ := props["TYPE"]
+		if (typ == "Mains" || strings.HasPrefix(typ, "USB")) && props["ONLINE"] == "1" {
+			return true
+		}
+	}
+	return false
+}
+
+func (l *LinuxPowerSource) update(props map[string]string, externalPower bool) {
+	value := func(key string) int64 {
+		return util.ParseInt64OrDefault(props[key], -1)
+	}
+	// milli converts a micro unit value to milli units, keeping -1 for missing values
+	milli := func(key string) int64 {
+		if v := value(key); v >= 0 {
+			return v / 1000
+		}
+		return -1
+	}
+	status := props["STATUS"]
+	l.charging = status == "Charging"
+	l.discharging = status == "Discharging"
+	// "Not charging" and "Unknown" do not tell whether an adapter is plugged in
+	l.powerOnLine = externalPower
+
+	// sysfs reports micro units: µV, µA, µW, µWh and µAh
+	l.voltage = -1
+	if voltage := value("VOLTAGE_NOW"); voltage >= 0 {
+		l.voltage = float64(voltage) / 1_000_000
+	}
+	l.amperage, l.powerUsageRate = 0, 0
+	current, power := value("CURRENT_NOW"), value("POWER_NOW")
+	if current >= 0 {
+		l.amperage = float64(current) / 1000
+	}
+	if power >= 0 {
+		l.powerUsageRate = float64(power) / 1000
+	}
+	// batteries report either the current or the power
+	if current < 0 && l.voltage > 0 {
+		l.amperage = l.powerUsageRate / l.voltage
+	} else if power < 0 && l.voltage > 0 {
+		l.powerUsageRate = l.voltage * l.amperage
+	}
+	if l.discharging {
+		l.amperage, l.powerUsageRate = -l.amperage, -l.powerUsageRate
+	}
+
+	switch {
+	case value("ENERGY_NOW") >= 0:
+		l.capacityUnits = hardware.CapacityMWh
+		l.currentCapacity = milli("ENERGY_NOW")
+		l.maxCapacity = milli("ENERGY_FULL")
+		l.designCapacity = milli("ENERGY_FULL_DESIGN")
+	case value("CHARGE_NOW") >= 0:
+		l.capacityUnits = hardware.CapacityMAh
+		l.currentCapacity = milli("CHARGE_NOW")
+		l.maxCapacity = milli("CHARGE_FULL")
+		l.designCapacity = milli("CHARGE_FULL_DESIGN")
+	default:
+		l.capacityUnits = hardware.CapacityRelative
+		l.currentCapacity = value("CAPACITY")
+		l.maxCapacity = 100
+		l.designCapacity = 100
+	}
+	if capacity := value("CAPACITY"); capacity >= 0 {
+		l.remainingCapacityPercent = float64(capacity) / 100
+	} else if l.currentCapacity >= 0 && l.maxCapacity > 0 {
+		l.remainingCapacityPercent = float64(l.currentCapacity) / float64(l.maxCapacity)
+	}
+
+	l.timeRemainingEstimated = hardware.TimeRemainingUnknown
+	if l.powerOnLine {
+		l.timeRemainingEstimated = hardware.TimeRemainingUnlimited
+	} else if seconds := value("TIME_TO_EMPTY_NOW"); seconds > 0 {
+		l.timeRemainingEstimated = float64(seconds)
+	} else if l.capacityUnits == hardware.CapacityMWh && l.powerUsageRate < 0 {
+		l.timeRemainingEstimated = float64(l.currentCapacity) / -l.powerUsageRate * 3600
+	} else if l.capacityUnits == hardware.CapacityMAh && l.amperage < 0 {
+		l.timeRemainingEstimated = float64(l.currentCapacity) / -l.amperage * 3600
+	}
+	l.cycleCount = int(value("CYCLE_COUNT"))
+}
+
+func PowerSources() []hardware.PowerSource {
+	sources := make([]hardware.PowerSource, 0)
+	dirs, _ := filepath.Glob(filepath.Join(rootPath(sysPower), "*"))
+	sort.Strings(dirs)
+	externalPower := externalPowerOnLine()
+	for _, dir := range dirs {
+		props := readPowerSupply(dir)
+		// peripherals such as wireless mice report their battery with scope Device
+		if props["TYPE"] != "Battery" || props["PRESENT"] == "0" || props["SCOPE"] == "Device" {
+			continue
+		}
+		source := &LinuxPowerSource{
+			dir:          dir,
+			name:         util.StringValueOrDefault(props["NAME"], filepath.Base(dir)),
+			deviceName:   util.StringValueOrDefault(props["MODEL_NAME"], util.Unknown),
+			chemistry:    util.StringValueOrDefault(props["TECHNOLOGY"], util.Unknown),
+			manufacturer: util.StringValueOrDefault(props["MANUFACTURER"], util.Unknown),
+			serialNumber: util.StringValueOrDefault(props["SERIAL_NUMBER"], util.Unknown),
+		}
+		source.update(props, externalPower)
+		sources = append(sources, source)
+	}
+	return sources
+}
@@ -0,0 +1,78 @@
+/*
+ * Copyright 2016-2024 The OSHI Project Contributors
+ * SPDX-License-Identifier: MIT
+ */
+
+package linux
+
+import (
+	"goshi/sysinfo/hardware"
+	"goshi/util"
+	"reflect"
+	"testing"
+)
+
+func TestPowerSources(t *testing.T) {
+	type state struct {
+		powerOnLine, charging, discharging bool
+		remaining, timeRemaining           float64
+		powerUsageRate, voltage, amperage  float64
+	}
+	type capacity struct {
+		units                hardware.CapacityUnits
+		current, max, design int64
+		cycleCount           int
+	}
+	tests := []struct {
+		tree     string
+		names    []string
+		info     []string
+		state    state
+		capacity capacity
+	}{
+		{
+			// the battery of the wireless mouse has scope Device
+			tree:  "x86-hybrid",
+			names: []string{"BAT0"},
+			info:  []string{"BAT0", "DELL 7FJ9225", "Li-ion", "SMP", "2436"},
+			// the AC adapter is online
+			state:    state{true, true, false, 0.62, hardware.TimeRemainingUnlimited, 18900, 12.6, 1500},
+			capacity: capacity{hardware.CapacityMAh, 2500, 4000, 4500, 45},
+		},
+		{
+			// the usb charger is offline and "Not charging" alone does not mean external power
+			tree:     "arm64-tri-cluster",
+			names:    []string{"battery"},
+			info:     []string{"battery", util.Unknown, "Li-poly", util.Unknown, util.Unknown},
+			state:    state{false, false, false, 0.8, hardware.TimeRemainingUnknown, 0, -1, 0},
+			capacity: capacity{hardware.CapacityMWh, 30000, -1, -1, -1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.tree, func(t *testing.T) {
+			setFixtureRoot(t, tt.tree)
+			sources := PowerSources()
+			names := make([]string, 0)
+			for _, s := range sources {
+				names = append(names, s.Name())
+			}
+			if !reflect.DeepEqual(names, tt.names) {
+				t.Fatalf("power sources = %v, want %v", names, tt.names)
+			}
+			s := sources[0]
+			if got := []string{s.Name(), s.DeviceName(), s.Chemistry(), s.Manufacturer(), s.SerialNumber()}; !reflect.DeepEqual(got, tt.info) {
+				t.Errorf("info = %q, want %q", got, tt.info)
+			}
+			got := state{s.PowerOnLine(), s.Charging(), s.Discharging(), s.RemainingCapacityPercent(), s.TimeRemainingEstimated(), s.PowerUsageRate(), s.Voltage(), s.Amperage()}
+			if got != tt.state {
+				t.Errorf("state = %+v, want %+v", got, tt.state)
+			}
+			if got := (capacity{s.CapacityUnits(), s.CurrentCapacity(), s.MaxCapacity(), s.DesignCapacity(), s.CycleCount()}); got != tt.capacity {
+				t.Errorf("capacity = %+v, want %+v", got, tt.capacity)
+			}
+			if !s.UpdateAttributes() {
+				t.Errorf("%s is gone after an update", s.Name())
+			}
+		})
+	}
+}
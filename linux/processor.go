@@ -1,9 +1,546 @@
+/*
+ * Copyright 2016-2024 The OSHI Project Contributors
+ * SPDX-License-Identifier: MIT
+ */
+
 package linux
 
 import (
 	"errors"
+	"fmt"
+	set "github.com/deckarep/golang-set/v2"
+	"goshi/internal/cpuid"
+	"goshi/sysinfo/hardware"
+	"goshi/util"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	// USER_HZ, the unit of the /proc/stat counters, is 100 on every supported architecture
+	userHz = 100
+	// relative difference of cpu_capacity values below which cpus are in the same cluster
+	capacityTolerance = 0.1
+)
+
+var (
+	cpuDirRegex   = regexp.MustCompile(`^cpu[0-9]+$`)
+	nodeDirRegex  = regexp.MustCompile(`^node[0-9]+$`)
+	cacheDirRegex = regexp.MustCompile(`^index[0-9]+$`)
+
+	cacheTypes = map[string]hardware.CacheType{
+		"Unified":     hardware.CacheUnified,
+		"Instruction": hardware.CacheInstruction,
+		"Data":        hardware.CacheData,
+	}
+
+	// bit positions of the cpuid leaf 1 edx flags, as reported in /proc/cpuinfo
+	cpuidEdxFlags = map[string]int{
+		"fpu": 0, "vme": 1, "de": 2, "pse": 3, "tsc": 4, "msr": 5, "pae": 6, "mce": 7,
+		"cx8": 8, "apic": 9, "sep": 11, "mtrr": 12, "pge": 13, "mca": 14, "cmov": 15,
+		"pat": 16, "pse36": 17, "pn": 18, "clflush": 19, "dts": 21, "acpi": 22,
+		"mmx": 23, "fxsr": 24, "sse": 25, "sse2": 26, "ss": 27, "ht": 28, "tm": 29,
+		"ia64": 30, "pbe": 31,
+	}
 )
 
-func Processor() (internal.CentralProcessor, error) {
-	return internal.CentralProcessor{}, errors.New("not implemented")
+type LinuxCentralProcessor struct {
+	hardware.CpuTicks
+	processorIdentifier  hardware.ProcessorIdentifier
+	physicalPackageCount int
+	logicalProcessors    []hardware.LogicalProcessor
+	physicalProcessors   []hardware.PhysicalProcessor
+	processorCaches      []hardware.ProcessorCache
+	maxFreq              int64
+	currentFreq          func() []int64
+}
+
+func (l LinuxCentralProcessor) ProcessorIdentifier() hardware.ProcessorIdentifier {
+	return l.processorIdentifier
+}
+
+func (l LinuxCentralProcessor) PhysicalPackageCount() int {
+	return l.physicalPackageCount
+}
+
+func (l LinuxCentralProcessor) PhysicalProcessorCount() int {
+	return len(l.physicalProcessors)
+}
+
+func (l LinuxCentralProcessor) LogicalProcessorCount() int {
+	return len(l.logicalProcessors)
+}
+
+func (l LinuxCentralProcessor) LogicalProcessors() []hardware.LogicalProcessor {
+	return l.logicalProcessors
+}
+
+func (l LinuxCentralProcessor) PhysicalProcessors() []hardware.PhysicalProcessor {
+	return l.physicalProcessors
+}
+
+func (l LinuxCentralProcessor) CoresByEfficiency() (performance, efficiency []hardware.PhysicalProcessor) {
+	return hardware.CoresByEfficiency(l.physicalProcessors)
+}
+
+func (l LinuxCentralProcessor) ProcessorCaches() []hardware.ProcessorCache {
+	return l.processorCaches
+}
+
+func (l LinuxCentralProcessor) MaxFreq() int64 {
+	return l.maxFreq
+}
+
+func (l LinuxCentralProcessor) CurrentFreq() []int64 {
+	return l.currentFreq()
+}
+
+func (l LinuxCentralProcessor) FrequencyPolicies() []hardware.FrequencyPolicy {
+	return frequencyPolicies()
+}
+
+func (l LinuxCentralProcessor) SystemLoadAverage(nelem int) []float64 {
+	average := make([]float64, min(max(nelem, 1), 3))
+	fields := strings.Fields(util.ReadString(rootPath(procLoadAvg)))
+	for i := range average {
+		average[i] = -1
+		if i < len(fields) {
+			if val, err := strconv.ParseFloat(fields[i], 64); err == nil {
+				average[i] = val
+			}
+		}
+	}
+	return average
+}
+
+type logicalProcessor struct {
+	processorNumber, physicalProcessorNumber, physicalPackageNumber, numaNode int
+}
+
+func splitCpuInfoLine(line string) (string, string, bool) {
+	key, value, found := strings.Cut(line, ":")
+	if !found {
+		return "", "", false
+	}
+	return strings.ToLower(strings.TrimSpace(key)), strings.TrimSpace(value), true
+}
+
+func processorID(stepping, model, family string, flags []string) string {
+	var id uint64
+	s := uint64(util.ParseInt64OrDefault(stepping, 0))
+	m := uint64(util.ParseInt64OrDefault(model, 0))
+	f := uint64(util.ParseInt64OrDefault(family, 0))
+	// 3:0 stepping, 19:16 and 7:4 model, 27:20 and 11:8 family
+	id |= s & 0xf
+	id |= (m & 0x0f) << 4
+	id |= (m & 0xf0) << 12
+	id |= (f & 0x0f) << 8
+	id |= (f & 0xf0) << 16
+	for _, flag := range flags {
+		if bit, exists := cpuidEdxFlags[flag]; exists {
+			id |= 1 << (32 + bit)
+		}
+	}
+	return fmt.Sprintf("%016X", id)
+}
+
+func processorIdentifier() (hardware.ProcessorIdentifier, error) {
+	path := rootPath(procCpuInfo)
+	lines := util.ReadLines(path)
+	if lines == nil {
+		return hardware.ProcessorIdentifier{}, fmt.Errorf("cpuinfo: cannot read %s", path)
+	}
+	var vendor, name, family, model, stepping, armVariant, armRevision string
+	var flags []string
+	var freq int64
+	is64bit := false
+	for _, line := range lines {
+		key, value, ok := splitCpuInfoLine(line)
+		if !ok || len(value) == 0 {
+			continue
+		}
+		switch key {
+		case "vendor_id", "cpu implementer":
+			vendor = value
+		case "model name", "processor":
+			// some arm chips report the name under processor, ignore the processor number
+			if _, err := strconv.Atoi(value); err != nil {
+				name = value
+			}
+		case "flags", "features":
+			flags = strings.Fields(strings.ToLower(value))
+			for _, flag := range flags {
+				if flag == "lm" {
+					is64bit = true
+				}
+			}
+		case "stepping":
+			stepping = value
+		case "cpu variant":
+			armVariant = value
+		case "cpu revision":
+			armRevision = value
+		case "model", "cpu part":
+			model = value
+		case "cpu family":
+			family = value
+		case "cpu architecture":
+			family = value
+			// armv8 and above are 64-bit
+			if util.ParseInt64OrDefault(value, 0) >= 8 {
+				is64bit = true
+			}
+		case "cpu mhz":
+			if mhz, err := strconv.ParseFloat(value, 64); err == nil && freq == 0 {
+				freq = int64(mhz * 1_000_000)
+			}
+		default:
+		}
+	}
+	if len(name) == 0 {
+		name = strings.Trim(util.ReadString(rootPath(procModel)), "\x00")
+	}
+	id := ""
+	// cpuid describes the live processor, not the one of a captured filesystem tree
+	if info, ok := cpuid.Read(); ok && Root() == "/" {
+		vendor = util.StringValueOrDefault(info.Vendor, vendor)
+		name = util.StringValueOrDefault(info.Brand, name)
+		family, model, stepping = strconv.Itoa(info.Family), strconv.Itoa(info.Model), strconv.Itoa(info.Stepping)
+		id = info.ProcessorID
+		if info.Hypervisor {
+			flags = append(flags, "hypervisor")
+		}
+	}
+	if strings.Contains(name, "Hz") {
+		// prefer the vendor frequency in the name
+		freq = -1
+	}
+	if len(stepping) == 0 && len(armRevision) != 0 {
+		variant, err := strconv.ParseInt(armVariant, 0, 64)
+		if err != nil {
+			variant = 0
+		}
+		stepping = fmt.Sprintf("r%dp%s", variant, armRevision)
+	}
+	if len(id) == 0 {
+		id = processorID(stepping, model, family, flags)
+	}
+	features := append(hwcapFeatures(), flags...)
+	procId := hardware.NewProcessorIdentifier(
+		vendor, name, family, model, stepping, id, is64bit, freq, features,
+	)
+	return procId, nil
+}
+
+func sysfsLogicalProcessors() []logicalProcessor {
+	entries, err := filepath.Glob(filepath.Join(rootPath(sysCpu), "cpu*"))
+	if err != nil {
+		return nil
+	}
+	logProcs := make([]logicalProcessor, 0)
+	for _, entry := range entries {
+		base := filepath.Base(entry)
+		if !cpuDirRegex.MatchString(base) {
+			continue
+		}
+		topology := filepath.Join(entry, "topology")
+		pkg := util.ReadIntOrDefault(filepath.Join(topology, "physical_package_id"), -1)
+		core := util.ReadIntOrDefault(filepath.Join(topology, "core_id"), -1)
+		if pkg < 0 || core < 0 {
+			// offline or hidden processors have no topology
+			continue
+		}
+		num, _ := strconv.Atoi(strings.TrimPrefix(base, "cpu"))
+		logProcs = append(logProcs, logicalProcessor{
+			processorNumber:         num,
+			physicalProcessorNumber: core,
+			physicalPackageNumber:   pkg,
+		})
+	}
+	return logProcs
+}
+
+func cpuInfoLogicalProcessors() []logicalProcessor {
+	logProcs := make([]logicalProcessor, 0)
+	var cur *logicalProcessor
+	for _, line := range util.ReadLines(rootPath(procCpuInfo)) {
+		key, value, ok := splitCpuInfoLine(line)
+		if !ok {
+			continue
+		}
+		switch key {
+		case "processor":
+			num, err := strconv.Atoi(value)
+			if err != nil {
+				continue
+			}
+			logProcs = append(logProcs, logicalProcessor{processorNumber: num, physicalProcessorNumber: num})
+			cur = &logProcs[len(logProcs)-1]
+		case "physical id":
+			if cur != nil {
+				cur.physicalPackageNumber = int(util.ParseInt64OrDefault(value, 0))
+			}
+		case "core id":
+			if cur != nil {
+				cur.physicalProcessorNumber = int(util.ParseInt64OrDefault(value, 0))
+			}
+		default:
+		}
+	}
+	return logProcs
+}
+
+// numaNodes maps every logical processor to its numa node
+func numaNodes() map[int]int {
+	nodes := make(map[int]int)
+	entries, err := os.ReadDir(rootPath(sysNode))
+	if err != nil {
+		return nodes
+	}
+	for _, entry := range entries {
+		if !nodeDirRegex.MatchString(entry.Name()) {
+			continue
+		}
+		node, _ := strconv.Atoi(strings.TrimPrefix(entry.Name(), "node"))
+		cpus := util.ReadString(filepath.Join(rootPath(sysNode), entry.Name(), "cpulist"))
+		for _, cpu := range util.ParseIntList(cpus) {
+			nodes[cpu] = node
+		}
+	}
+	return nodes
+}
+
+func logicalProcessors() []logicalProcessor {
+	logProcs := sysfsLogicalProcessors()
+	if len(logProcs) == 0 {
+		logProcs = cpuInfoLogicalProcessors()
+	}
+	nodes := numaNodes()
+	for i := range logProcs {
+		logProcs[i].numaNode = nodes[logProcs[i].processorNumber]
+	}
+	sort.Slice(logProcs, func(i, j int) bool {
+		return logProcs[i].processorNumber < logProcs[j].processorNumber
+	})
+	return logProcs
+}
+
+// parseCacheSize parses sysfs cache sizes such as "48K" to bytes
+func parseCacheSize(size string) int64 {
+	multiplier := int64(1)
+	switch {
+	case strings.HasSuffix(size, "K"):
+		multiplier = 1 << 10
+	case strings.HasSuffix(size, "M"):
+		multiplier = 1 << 20
+	case strings.HasSuffix(size, "G"):
+		multiplier = 1 << 30
+	}
+	return util.ParseInt64OrDefault(strings.TrimRight(size, "KMG"), 0) * multiplier
+}
+
+func processorCaches(logProcs []logicalProcessor) []hardware.ProcessorCache {
+	caches := make([]hardware.ProcessorCache, 0)
+	// caches shared by several processors are listed under each of them
+	shared := set.NewSet[string]()
+	for _, logProc := range logProcs {
+		cacheDir := filepath.Join(rootPath(sysCpu), fmt.Sprintf("cpu%d", logProc.processorNumber), "cache")
+		entries, err := os.ReadDir(cacheDir)
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			if !cacheDirRegex.MatchString(entry.Name()) {
+				continue
+			}
+			index := filepath.Join(cacheDir, entry.Name())
+			level := util.ReadIntOrDefault(filepath.Join(index, "level"), 0)
+			typ := util.ReadString(filepath.Join(index, "type"))
+			sharedCpus := util.ReadString(filepath.Join(index, "shared_cpu_list"))
+			if len(sharedCpus) == 0 {
+				sharedCpus = strconv.Itoa(logProc.processorNumber)
+			}
+			if !shared.Add(fmt.Sprintf("%d/%s/%s", level, typ, sharedCpus)) {
+				continue
+			}
+			cacheType, exists := cacheTypes[typ]
+			if !exists {
+				cacheType = hardware.CacheUnified
+			}
+			caches = append(caches, hardware.NewProcessorCache(
+				level,
+				util.ReadIntOrDefault(filepath.Join(index, "ways_of_associativity"), 0),
+				util.ReadIntOrDefault(filepath.Join(index, "coherency_line_size"), 0),
+				parseCacheSize(util.ReadString(filepath.Join(index, "size"))),
+				cacheType,
+			))
+		}
+	}
+	sort.SliceStable(caches, func(i, j int) bool {
+		if caches[i].Level() != caches[j].Level() {
+			return caches[i].Level() < caches[j].Level()
+		}
+		return caches[i].Type() > caches[j].Type()
+	})
+	return caches
+}
+
+// parseStatTicks converts the counters of a /proc/stat cpu line to milliseconds
+func parseStatTicks(fields []string) []int64 {
+	ticks := make([]int64, hardware.TickTypeCount)
+	for i := range ticks {
+		if i+1 < len(fields) {
+			ticks[i] = util.ParseInt64OrDefault(fields[i+1], 0) * 1000 / userHz
+		}
+	}
+	return ticks
+}
+
+func systemCpuLoadTicks() ([]int64, error) {
+	path := rootPath(procStat)
+	for _, line := range util.ReadLines(path) {
+		fields := strings.Fields(line)
+		if len(fields) > 0 && fields[0] == "cpu" {
+			return parseStatTicks(fields), nil
+		}
+	}
+	return nil, fmt.Errorf("cpu: no cpu ticks in %s", path)
+}
+
+// processorCpuLoadTicks reads the ticks of every logical processor, offline processors have zero ticks
+func processorCpuLoadTicks(logProcs []logicalProcessor) ([][]int64, error) {
+	path := rootPath(procStat)
+	lines := util.ReadLines(path)
+	if lines == nil {
+		return nil, fmt.Errorf("cpu: cannot read %s", path)
+	}
+	ticks := make([][]int64, len(logProcs))
+	indices := make(map[int]int)
+	for i, logProc := range logProcs {
+		ticks[i] = make([]int64, hardware.TickTypeCount)
+		indices[logProc.processorNumber] = i
+	}
+	for _, line := range lines {
+		fields := strings.Fields(line)
+		if len(fields) == 0 || fields[0] == "cpu" || !strings.HasPrefix(fields[0], "cpu") {
+			continue
+		}
+		cpu, err := strconv.Atoi(strings.TrimPrefix(fields[0], "cpu"))
+		if err != nil {
+			continue
+		}
+		if i, exists := indices[cpu]; exists {
+			ticks[i] = parseStatTicks(fields)
+		}
+	}
+	return ticks, nil
+}
+
+// efficiencyClasses maps processor numbers to their efficiency class. Intel hybrid processors
+// register separate PMUs for their P-cores and E-cores, ARM big.LITTLE systems expose the relative
+// capacity of every cpu, ranked here from 0 for the smallest. A nil map means a non-hybrid processor.
+func efficiencyClasses(logProcs []logicalProcessor) map[int]int {
+	if atom := util.ReadString(rootPath(sysCpuAtom)); len(atom) != 0 {
+		classes := make(map[int]int)
+		for _, cpu := range util.ParseIntList(util.ReadString(rootPath(sysCpuCore))) {
+			classes[cpu] = 1
+		}
+		for _, cpu := range util.ParseIntList(atom) {
+			classes[cpu] = 0
+		}
+		return classes
+	}
+	capacities := make(map[int]int64)
+	distinct := set.NewSet[int64]()
+	for _, logProc := range logProcs {
+		path := rootPath(filepath.Join(sysCpu, fmt.Sprintf("cpu%d", logProc.processorNumber), "cpu_capacity"))
+		if capacity := util.ReadInt64OrDefault(path, -1); capacity >= 0 {
+			capacities[logProc.processorNumber] = capacity
+			distinct.Add(capacity)
+		}
+	}
+	ranks, clusters := rankCapacities(distinct.ToSlice())
+	if clusters < 2 {
+		return nil
+	}
+	classes := make(map[int]int)
+	for cpu, capacity := range capacities {
+		classes[cpu] = ranks[capacity]
+	}
+	return classes
+}
+
+// rankCapacities clusters the distinct capacities and ranks the clusters from 0 for the smallest. A
+// cluster starts at its smallest capacity and holds the capacities within capacityTolerance of it, so
+// that a chain of small steps does not merge clusters that are far apart.
+func rankCapacities(capacities []int64) (map[int64]int, int) {
+	sorted := append([]int64(nil), capacities...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	ranks := make(map[int64]int)
+	rank := -1
+	var first int64
+	for i, capacity := range sorted {
+		if i == 0 || float64(capacity) > float64(first)*(1+capacityTolerance) {
+			rank++
+			first = capacity
+		}
+		ranks[capacity] = rank
+	}
+	return ranks, rank + 1
+}
+
+func Processor() (hardware.CentralProcessor, error) {
+	procId, err := processorIdentifier()
+	if err != nil {
+		return nil, err
+	}
+	logProcs := logicalProcessors()
+	if len(logProcs) == 0 {
+		return nil, errors.New("cpu: no logical processors found")
+	}
+	classes := efficiencyClasses(logProcs)
+	coreClasses := make(map[int]int)
+	keys := set.NewSet[int]()
+	physPkgs := set.NewSet[int]()
+	logicalProcessors := make([]hardware.LogicalProcessor, 0, len(logProcs))
+	for _, logProc := range logProcs {
+		key := logProc.physicalPackageNumber<<16 + logProc.physicalProcessorNumber
+		keys.Add(key)
+		coreClasses[key] = max(coreClasses[key], classes[logProc.processorNumber])
+		physPkgs.Add(logProc.physicalPackageNumber)
+		logicalProcessors = append(logicalProcessors, hardware.NewLogicalProcessor(
+			logProc.processorNumber,
+			logProc.physicalProcessorNumber,
+			logProc.physicalPackageNumber,
+			logProc.numaNode,
+			0,
+		))
+	}
+	pkgCoreKeys := keys.ToSlice()
+	sort.Ints(pkgCoreKeys)
+	physicalProcessors := make([]hardware.PhysicalProcessor, 0, len(pkgCoreKeys))
+	for _, key := range pkgCoreKeys {
+		physicalProcessors = append(physicalProcessors, hardware.NewPhysicalProcessor(
+			key>>16, key&0xFFFF, coreClasses[key], procId.ProcessorID(),
+		))
+	}
+	proc := LinuxCentralProcessor{
+		CpuTicks: hardware.NewCpuTicks(systemCpuLoadTicks, func() ([][]int64, error) {
+			return processorCpuLoadTicks(logProcs)
+		}, len(logProcs)),
+		processorIdentifier:  procId,
+		physicalPackageCount: physPkgs.Cardinality(),
+		logicalProcessors:    logicalProcessors,
+		physicalProcessors:   physicalProcessors,
+		processorCaches:      processorCaches(logProcs),
+		maxFreq:              maxFreq(logProcs, procId),
+		currentFreq: util.Memoize(func() []int64 {
+			return currentFreq(logProcs)
+		}, util.DefaultExpiration()),
+	}
+	return proc, nil
 }
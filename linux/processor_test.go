@@ -0,0 +1,400 @@
+/*
+ * Copyright 2016-2024 The OSHI Project Contributors
+ * SPDX-License-Identifier: MIT
+ */
+
+package linux
+
+import (
+	"goshi/sysinfo/hardware"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func coreNumbers(procs []hardware.PhysicalProcessor) []int {
+	cores := make([]int, 0, len(procs))
+	for _, p := range procs {
+		cores = append(cores, p.PhysicalProcessorNumber())
+	}
+	return cores
+}
+
+func TestProcessorIdentifier(t *testing.T) {
+	tests := []struct {
+		tree                                  string
+		vendor, name, family, model, stepping string
+		processorID                           string
+		frequency                             int64
+	}{
+		{
+			tree:     "x86-hybrid",
+			vendor:   "GenuineIntel",
+			name:     "12th Gen Intel(R) Core(TM) i7-12700H",
+			family:   "6",
+			model:    "154",
+			stepping: "3",
+			// the edx flags of cpuid leaf 1 and the signature of family 6 model 0x9A stepping 3
+			processorID: "BFEBFBFF000906A3",
+			frequency:   2_700_000_000,
+		},
+		{
+			tree: "arm64-tri-cluster",
+			// the implementer code is resolved to its name
+			vendor: "ARM",
+			// arm chips have no model name, the device tree names the board
+			name:      "Qualcomm Technologies, Inc. SM8450 QRD",
+			family:    "8",
+			model:     "0xd48",
+			stepping:  "r2p0",
+			frequency: -1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.tree, func(t *testing.T) {
+			setFixtureRoot(t, tt.tree)
+			p, err := Processor()
+			if err != nil {
+				t.Fatal(err)
+			}
+			id := p.ProcessorIdentifier()
+			got := []string{id.Vendor(), id.Name(), id.Family(), id.Model(), id.Stepping()}
+			if want := []string{tt.vendor, tt.name, tt.family, tt.model, tt.stepping}; !reflect.DeepEqual(got, want) {
+				t.Errorf("identifier = %q, want %q", got, want)
+			}
+			if len(tt.processorID) != 0 && id.ProcessorID() != tt.processorID {
+				t.Errorf("processor id = %s, want %s", id.ProcessorID(), tt.processorID)
+			}
+			if !id.Is64Bit() {
+				t.Errorf("expected a 64 bit processor")
+			}
+			if id.Frequency() != tt.frequency {
+				t.Errorf("frequency = %d, want %d", id.Frequency(), tt.frequency)
+			}
+		})
+	}
+}
+
+func TestProcessorCounts(t *testing.T) {
+	tests := []struct {
+		tree                        string
+		logical, physical, packages int
+	}{
+		// one hyper-threaded P-core and two E-cores
+		{"x86-hybrid", 4, 3, 1},
+		{"arm64-tri-cluster", 8, 8, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.tree, func(t *testing.T) {
+			setFixtureRoot(t, tt.tree)
+			p, err := Processor()
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := []int{p.LogicalProcessorCount(), p.PhysicalProcessorCount(), p.PhysicalPackageCount()}
+			if want := []int{tt.logical, tt.physical, tt.packages}; !reflect.DeepEqual(got, want) {
+				t.Errorf("logical, physical and package counts = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestProcessorFeatures(t *testing.T) {
+	tests := []struct {
+		tree            string
+		present, absent []string
+	}{
+		// pni is reported as sse3
+		{"x86-hybrid", []string{"avx2", "sse3", "sse4_2", "sha_ni", "PNI"}, []string{"pni", "neon", "avx512f"}},
+		// asimd is reported as neon, the auxiliary vector of the test process is not read for
+		// another root so sve is missing
+		{"arm64-tri-cluster", []string{"aes", "bf16", "neon", "sha3", "asimd"}, []string{"asimd", "sve", "avx2"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.tree, func(t *testing.T) {
+			setFixtureRoot(t, tt.tree)
+			p, err := Processor()
+			if err != nil {
+				t.Fatal(err)
+			}
+			id := p.ProcessorIdentifier()
+			features := id.Features()
+			if !slices.IsSorted(features) {
+				t.Errorf("features are not sorted: %v", features)
+			}
+			for _, feature := range tt.present {
+				if !id.HasFeature(feature) {
+					t.Errorf("missing feature %s", feature)
+				}
+			}
+			for _, feature := range tt.absent {
+				if slices.Contains(features, feature) {
+					t.Errorf("unexpected feature %s", feature)
+				}
+			}
+		})
+	}
+}
+
+func TestProcessorLists(t *testing.T) {
+	tests := []struct {
+		tree string
+		// the core of every logical processor
+		logicalCores []int
+		cores        []int
+	}{
+		// the two threads of the P-core, then the E-cores with their core_id
+		{"x86-hybrid", []int{0, 0, 8, 9}, []int{0, 8, 9}},
+		{"arm64-tri-cluster", []int{0, 1, 2, 3, 4, 5, 6, 7}, []int{0, 1, 2, 3, 4, 5, 6, 7}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.tree, func(t *testing.T) {
+			setFixtureRoot(t, tt.tree)
+			p, err := Processor()
+			if err != nil {
+				t.Fatal(err)
+			}
+			logicalCores := make([]int, 0)
+			for i, logProc := range p.LogicalProcessors() {
+				if logProc.ProcessorNumber() != i {
+					t.Errorf("logical processor %d numbered %d", i, logProc.ProcessorNumber())
+				}
+				if logProc.NumaNode() != 0 || logProc.PhysicalPackageNumber() != 0 || logProc.ProcessorGroup() != 0 {
+					t.Errorf("logical processor %d on node %d package %d group %d", i, logProc.NumaNode(), logProc.PhysicalPackageNumber(), logProc.ProcessorGroup())
+				}
+				logicalCores = append(logicalCores, logProc.PhysicalProcessorNumber())
+			}
+			if !reflect.DeepEqual(logicalCores, tt.logicalCores) {
+				t.Errorf("cores of the logical processors = %v, want %v", logicalCores, tt.logicalCores)
+			}
+			id := p.ProcessorIdentifier()
+			cores := p.PhysicalProcessors()
+			if got := coreNumbers(cores); !reflect.DeepEqual(got, tt.cores) {
+				t.Errorf("physical processors = %v, want %v", got, tt.cores)
+			}
+			for _, core := range cores {
+				if core.PhysicalPackageNumber() != 0 || core.IdString() != id.ProcessorID() {
+					t.Errorf("core %d in package %d with id %q", core.PhysicalProcessorNumber(), core.PhysicalPackageNumber(), core.IdString())
+				}
+			}
+		})
+	}
+}
+
+func TestProcessorCaches(t *testing.T) {
+	setFixtureRoot(t, "x86-hybrid")
+	p, err := Processor()
+	if err != nil {
+		t.Fatal(err)
+	}
+	type cache struct {
+		level, associativity, lineSize int
+		size                           int64
+		typ                            hardware.CacheType
+	}
+	// the caches both threads of the P-core list once, the L1 caches of each E-core, the L2 of the
+	// E-core cluster once and the L3 all cores share once
+	want := []cache{
+		{1, 12, 64, 48 << 10, hardware.CacheData},
+		{1, 8, 64, 32 << 10, hardware.CacheData},
+		{1, 8, 64, 32 << 10, hardware.CacheData},
+		{1, 8, 64, 32 << 10, hardware.CacheInstruction},
+		{1, 8, 64, 64 << 10, hardware.CacheInstruction},
+		{1, 8, 64, 64 << 10, hardware.CacheInstruction},
+		{2, 10, 64, 1280 << 10, hardware.CacheUnified},
+		{2, 16, 64, 2 << 20, hardware.CacheUnified},
+		{3, 12, 64, 24 << 20, hardware.CacheUnified},
+	}
+	got := make([]cache, 0)
+	for _, c := range p.ProcessorCaches() {
+		got = append(got, cache{c.Level(), c.Associativity(), c.LineSize(), c.CacheSize(), c.Type()})
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("caches = %v, want %v", got, want)
+	}
+
+	// the arm tree has no cache directories
+	setFixtureRoot(t, "arm64-tri-cluster")
+	if p, err = Processor(); err != nil {
+		t.Fatal(err)
+	}
+	if caches := p.ProcessorCaches(); len(caches) != 0 {
+		t.Errorf("got %d caches, want none", len(caches))
+	}
+}
+
+func TestProcessorEfficiency(t *testing.T) {
+	tests := []struct {
+		tree                              string
+		efficiency                        []int
+		performanceCores, efficiencyCores []int
+	}{
+		{
+			tree: "x86-hybrid",
+			// the hyper-threaded P-core and two E-cores from the cpu_core and cpu_atom PMUs
+			efficiency:       []int{1, 0, 0},
+			performanceCores: []int{0},
+			efficiencyCores:  []int{8, 9},
+		},
+		{
+			tree: "arm64-tri-cluster",
+			// capacities 245, 871, 889 and 1024: 889 is within 10% of 871 and 1024 is not
+			efficiency:       []int{0, 0, 0, 0, 1, 1, 1, 2},
+			performanceCores: []int{4, 5, 6, 7},
+			efficiencyCores:  []int{0, 1, 2, 3},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.tree, func(t *testing.T) {
+			setFixtureRoot(t, tt.tree)
+			p, err := Processor()
+			if err != nil {
+				t.Fatal(err)
+			}
+			efficiency := make([]int, 0)
+			for _, core := range p.PhysicalProcessors() {
+				efficiency = append(efficiency, core.Efficiency())
+			}
+			if !reflect.DeepEqual(efficiency, tt.efficiency) {
+				t.Errorf("efficiency classes = %v, want %v", efficiency, tt.efficiency)
+			}
+			performance, efficient := p.CoresByEfficiency()
+			if got := coreNumbers(performance); !reflect.DeepEqual(got, tt.performanceCores) {
+				t.Errorf("performance cores = %v, want %v", got, tt.performanceCores)
+			}
+			if got := coreNumbers(efficient); !reflect.DeepEqual(got, tt.efficiencyCores) {
+				t.Errorf("efficiency cores = %v, want %v", got, tt.efficiencyCores)
+			}
+		})
+	}
+}
+
+func TestRankCapacities(t *testing.T) {
+	tests := []struct {
+		name       string
+		capacities []int64
+		ranks      []int
+		clusters   int
+	}{
+		{"none", nil, nil, 0},
+		{"symmetric", []int64{1024}, []int{0}, 1},
+		{"big.LITTLE", []int64{1024, 446}, []int{1, 0}, 2},
+		// 9% steps chain past the tolerance, a cluster is measured from its first capacity
+		{"chain", []int64{100, 109, 118, 128, 140}, []int{0, 0, 1, 1, 2}, 3},
+		{"boundary", []int64{100, 110, 111}, []int{0, 0, 1}, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ranks, clusters := rankCapacities(tt.capacities)
+			got := make([]int, 0)
+			for _, capacity := range tt.capacities {
+				got = append(got, ranks[capacity])
+			}
+			if clusters != tt.clusters || len(got) != len(tt.ranks) || (len(got) != 0 && !reflect.DeepEqual(got, tt.ranks)) {
+				t.Errorf("ranks = %v in %d clusters, want %v in %d", got, clusters, tt.ranks, tt.clusters)
+			}
+		})
+	}
+}
+
+func TestProcessorFrequency(t *testing.T) {
+	tests := []struct {
+		tree        string
+		maxFreq     int64
+		currentFreq []int64
+		policies    []string
+		processors  [][]int
+		boost       [2]bool
+	}{
+		{
+			tree:    "x86-hybrid",
+			maxFreq: 4_700_000_000,
+			// cpu2 has no scaling_cur_freq and falls back to cpuinfo
+			currentFreq: []int64{2_700_000_000, 800_000_000, 400_000_000, 1_200_000_000},
+			policies:    []string{"policy0", "policy1", "policy2", "policy3"},
+			processors:  [][]int{{0}, {1}, {2}, {3}},
+			// intel_pstate/no_turbo is 0
+			boost: [2]bool{true, true},
+		},
+		{
+			tree:    "arm64-tri-cluster",
+			maxFreq: 2_995_200_000,
+			// arm cpuinfo has no frequency to fall back to
+			currentFreq: []int64{892_800_000, 892_800_000, 892_800_000, 892_800_000, 1_248_000_000, 1_248_000_000, 1_248_000_000, -1},
+			policies:    []string{"policy0", "policy4", "policy7"},
+			processors:  [][]int{{0, 1, 2, 3}, {4, 5, 6}, {7}},
+			boost:       [2]bool{false, false},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.tree, func(t *testing.T) {
+			setFixtureRoot(t, tt.tree)
+			p, err := Processor()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if p.MaxFreq() != tt.maxFreq {
+				t.Errorf("max frequency = %d, want %d", p.MaxFreq(), tt.maxFreq)
+			}
+			if got := p.CurrentFreq(); !reflect.DeepEqual(got, tt.currentFreq) {
+				t.Errorf("current frequencies = %v, want %v", got, tt.currentFreq)
+			}
+			names := make([]string, 0)
+			processors := make([][]int, 0)
+			for _, policy := range p.FrequencyPolicies() {
+				names = append(names, policy.Name())
+				processors = append(processors, policy.Processors())
+				if enabled, ok := policy.Boost(); enabled != tt.boost[0] || ok != tt.boost[1] {
+					t.Errorf("%s boost = %t, %t, want %v", policy.Name(), enabled, ok, tt.boost)
+				}
+			}
+			if !reflect.DeepEqual(names, tt.policies) || !reflect.DeepEqual(processors, tt.processors) {
+				t.Errorf("policies = %v %v, want %v %v", names, processors, tt.policies, tt.processors)
+			}
+		})
+	}
+}
+
+func TestFrequencyPolicy(t *testing.T) {
+	setFixtureRoot(t, "arm64-tri-cluster")
+	policies := frequencyPolicies()
+	if len(policies) != 3 {
+		t.Fatalf("got %d policies, want 3", len(policies))
+	}
+	p := policies[1]
+	got := []any{p.Driver(), p.Governor(), p.MinFreq(), p.MaxFreq(), p.ScalingMinFreq(), p.ScalingMaxFreq()}
+	want := []any{"qcom-cpufreq-hw", "schedutil", int64(633_600_000), int64(2_496_000_000), int64(633_600_000), int64(2_496_000_000)}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("policy4 = %v, want %v", got, want)
+	}
+}
+
+func TestProcessorTicks(t *testing.T) {
+	setFixtureRoot(t, "x86-hybrid")
+	p, err := Processor()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// USER_HZ ticks in milliseconds
+	if got, want := p.SystemCpuLoadTicks(), []int64{100_000, 2000, 30_000, 800_000, 5000, 1000, 500, 0}; !reflect.DeepEqual(got, want) {
+		t.Errorf("system ticks = %v, want %v", got, want)
+	}
+	ticks := p.ProcessorCpuLoadTicks()
+	if len(ticks) != 4 || !reflect.DeepEqual(ticks[2], []int64{20_000, 300, 5000, 200_000, 1000, 100, 100, 0}) {
+		t.Errorf("processor ticks = %v", ticks)
+	}
+	// the counters of a captured tree do not move
+	if load := p.SystemCpuLoadBetweenTicks(p.SystemCpuLoadTicks()); load != 0 {
+		t.Errorf("load between identical ticks = %f", load)
+	}
+	if got, want := p.SystemLoadAverage(3), []float64{0.52, 0.58, 0.59}; !reflect.DeepEqual(got, want) {
+		t.Errorf("load average = %v, want %v", got, want)
+	}
+	// nelem is clamped between 1 and 3
+	if got := len(p.SystemLoadAverage(0)); got != 1 {
+		t.Errorf("%d averages for nelem 0, want 1", got)
+	}
+	if got := len(p.SystemLoadAverage(5)); got != 3 {
+		t.Errorf("%d averages for nelem 5, want 3", got)
+	}
+}
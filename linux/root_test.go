@@ -0,0 +1,106 @@
+/*
+ * Copyright 2016-2024 The OSHI Project Contributors
+ * SPDX-License-Identifier: MIT
+ */
+
+package linux
+
+import (
+	"goshi/util"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// setFixtureRoot points the readers at a copy of testdata/tree for the duration of the test. Module
+// zips do not allow colons in file names, so testdata escapes them as %3A in names and symlink
+// targets and the copy restores them. The trees are:
+//   - x86-hybrid, a 12th gen Intel laptop with one P-core and two E-cores, an nvme drive with btrfs
+//     subvolumes, a usb stick, hwmon chips and a charging battery
+//   - arm64-tri-cluster, a phone SoC with little, big and prime cores, an eMMC drive, thermal zones
+//     but no hwmon chip, and a battery that is not charging
+func setFixtureRoot(t *testing.T, tree string) {
+	t.Helper()
+	src := filepath.Join("testdata", tree)
+	dst := t.TempDir()
+	err := filepath.WalkDir(src, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		if rel, err = url.PathUnescape(rel); err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+		switch {
+		case d.Type()&fs.ModeSymlink != 0:
+			link, err := os.Readlink(path)
+			if err != nil {
+				return err
+			}
+			if link, err = url.PathUnescape(link); err != nil {
+				return err
+			}
+			return os.Symlink(link, target)
+		case d.IsDir():
+			return os.MkdirAll(target, 0o755)
+		default:
+			b, err := os.ReadFile(path)
+			if err != nil {
+				return err
+			}
+			return os.WriteFile(target, b, 0o644)
+		}
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	SetRoot(dst)
+	t.Cleanup(func() { SetRoot("") })
+}
+
+func TestSetRoot(t *testing.T) {
+	t.Setenv("GOSHI_UTIL_ROOT_PATH", "")
+	if got := rootPath(procCpuInfo); got != "/proc/cpuinfo" {
+		t.Errorf("default path = %s", got)
+	}
+	SetRoot("/mnt/target")
+	if got := rootPath(procCpuInfo); Root() != "/mnt/target" || got != "/mnt/target/proc/cpuinfo" {
+		t.Errorf("root %s gives path %s", Root(), got)
+	}
+	// an empty root restores the environment and then the default
+	t.Setenv("GOSHI_UTIL_ROOT_PATH", "/srv/capture")
+	SetRoot("")
+	if Root() != "/srv/capture" {
+		t.Errorf("root = %s, want the environment", Root())
+	}
+	t.Setenv("GOSHI_UTIL_ROOT_PATH", "")
+	if Root() != "/" {
+		t.Errorf("root = %s, want /", Root())
+	}
+}
+
+func TestFixtureRoot(t *testing.T) {
+	tests := []struct {
+		tree, path, want string
+	}{
+		// escaped colons in names
+		{"x86-hybrid", runUdevData + "/b259:0", "S:disk/by-id/nvme-Samsung_SSD_980_PRO_1TB_S5GXNF0R123456"},
+		// symlinks into /sys/devices
+		{"x86-hybrid", sysBlock + "/nvme0n1/size", "1000215216"},
+		{"arm64-tri-cluster", sysBlock + "/mmcblk0/device/serial", "0x1234abcd"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.tree, func(t *testing.T) {
+			setFixtureRoot(t, tt.tree)
+			if got := util.ReadLines(rootPath(tt.path)); len(got) == 0 || got[0] != tt.want {
+				t.Errorf("%s = %q, want %q first", tt.path, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,191 @@
+/*
+ * Copyright 2016-2024 The OSHI Project Contributors
+ * SPDX-License-Identifier: MIT
+ */
+
+package linux
+
+import (
+	"goshi/sysinfo/hardware"
+	"goshi/util"
+	"math"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var (
+	hwmonInputRegex = regexp.MustCompile(`^(temp|in|fan|curr|power|energy|humidity)([0-9]+)_input$`)
+	hwmonTypes      = []string{"temp", "in", "fan", "curr", "power", "energy", "humidity"}
+
+	// hwmon channel types with the divisor of their sysfs values and the unit it gives
+	hwmonUnits = map[string]struct {
+		divisor float64
+		unit    string
+	}{
+		"temp":     {1000, "°C"},
+		"in":       {1000, "V"},
+		"fan":      {1, "RPM"},
+		"curr":     {1000, "A"},
+		"power":    {1_000_000, "W"},
+		"energy":   {1_000_000, "J"},
+		"humidity": {1000, "%"},
+	}
+
+	// hwmon drivers of the cpu package sensors
+	cpuHwmonChips = []string{"coretemp", "k10temp", "zenpower"}
+	// thermal zones of the cpu, in order of preference
+	cpuThermalZones = []string{"x86_pkg_temp", "cpu-thermal", "cpu_thermal", "soc_thermal", "cpu"}
+)
+
+type LinuxSensors struct {
+	readings func() []hardware.SensorReading
+}
+
+func (l LinuxSensors) CpuTemperature() float64 {
+	readings := l.readings()
+	for _, chip := range cpuHwmonChips {
+		temp := math.NaN()
+		for _, r := range readings {
+			if r.Chip() != chip || r.Unit() != "°C" {
+				continue
+			}
+			// the package or die sensor rather than the hottest core
+			label := strings.ToLower(r.Label())
+			if strings.HasPrefix(label, "package") || label == "tdie" {
+				temp = r.Input()
+				break
+			}
+			if math.IsNaN(temp) || r.Input() > temp {
+				temp = r.Input()
+			}
+		}
+		if !math.IsNaN(temp) {
+			return temp
+		}
+	}
+	return thermalZoneTemperature()
+}
+
+func (l LinuxSensors) FanSpeeds() []int {
+	speeds := make([]int, 0)
+	for _, r := range l.readings() {
+		if r.Unit() == "RPM" {
+			speeds = append(speeds, int(r.Input()))
+		}
+	}
+	return speeds
+}
+
+func (l LinuxSensors) CpuVoltage() float64 {
+	for _, r := range l.readings() {
+		if r.Unit() != "V" {
+			continue
+		}
+		label := strings.ToLower(r.Label())
+		if strings.Contains(label, "vcore") || strings.Contains(label, "cpu") {
+			return r.Input()
+		}
+	}
+	return 0
+}
+
+func (l LinuxSensors) Readings() []hardware.SensorReading {
+	return l.readings()
+}
+
+// thermalZoneTemperature reads the cpu thermal zone, or the first zone if none is named after the cpu
+func thermalZoneTemperature() float64 {
+	zones, _ := filepath.Glob(filepath.Join(rootPath(sysThermal), "thermal_zone*"))
+	sort.Strings(zones)
+	temps := make(map[string]int64)
+	for _, zone := range zones {
+		temp := util.ReadInt64OrDefault(filepath.Join(zone, "temp"), math.MinInt64)
+		if temp == math.MinInt64 {
+			continue
+		}
+		if len(temps) == 0 {
+			temps[""] = temp
+		}
+		if typ := util.ReadString(filepath.Join(zone, "type")); len(typ) != 0 {
+			if _, exists := temps[typ]; !exists {
+				temps[typ] = temp
+			}
+		}
+	}
+	for _, typ := range append(cpuThermalZones, "") {
+		if temp, exists := temps[typ]; exists {
+			return float64(temp) / 1000
+		}
+	}
+	return 0
+}
+
+func readHwmonValue(path string, divisor float64) float64 {
+	val, err := strconv.ParseFloat(util.ReadString(path), 64)
+	if err != nil {
+		return math.NaN()
+	}
+	return val / divisor
+}
+
+func readHwmon() []hardware.SensorReading {
+	readings := make([]hardware.SensorReading, 0)
+	dirs, _ := filepath.Glob(filepath.Join(rootPath(sysHwmon), "hwmon*"))
+	sort.Slice(dirs, func(i, j int) bool {
+		a := util.ParseInt64OrDefault(strings.TrimPrefix(filepath.Base(dirs[i]), "hwmon"), 0)
+		b := util.ParseInt64OrDefault(strings.TrimPrefix(filepath.Base(dirs[j]), "hwmon"), 0)
+		return a < b
+	})
+	for _, dir := range dirs {
+		// older drivers keep their attributes on the parent device
+		if _, err := os.Stat(filepath.Join(dir, "name")); err != nil {
+			dir = filepath.Join(dir, "device")
+		}
+		chip := util.StringValueOrDefault(util.ReadString(filepath.Join(dir, "name")), util.Unknown)
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		channels := make([][]string, 0)
+		for _, entry := range entries {
+			if m := hwmonInputRegex.FindStringSubmatch(entry.Name()); m != nil {
+				channels = append(channels, m[1:])
+			}
+		}
+		sort.Slice(channels, func(i, j int) bool {
+			if channels[i][0] != channels[j][0] {
+				return slices.Index(hwmonTypes, channels[i][0]) < slices.Index(hwmonTypes, channels[j][0])
+			}
+			return util.ParseInt64OrDefault(channels[i][1], 0) < util.ParseInt64OrDefault(channels[j][1], 0)
+		})
+		for _, channel := range channels {
+			prefix := filepath.Join(dir, channel[0]+channel[1])
+			unit := hwmonUnits[channel[0]]
+			input := readHwmonValue(prefix+"_input", unit.divisor)
+			if math.IsNaN(input) {
+				continue
+			}
+			readings = append(readings, hardware.NewSensorReading(
+				chip,
+				util.StringValueOrDefault(util.ReadString(prefix+"_label"), channel[0]+channel[1]),
+				input,
+				readHwmonValue(prefix+"_min", unit.divisor),
+				readHwmonValue(prefix+"_max", unit.divisor),
+				readHwmonValue(prefix+"_crit", unit.divisor),
+				unit.unit,
+			))
+		}
+	}
+	return readings
+}
+
+func Sensors() hardware.Sensors {
+	return LinuxSensors{
+		readings: util.Memoize(readHwmon, util.DefaultExpiration()),
+	}
+}
@@ -0,0 +1,86 @@
+/*
+ * Copyright 2016-2024 The OSHI Project Contributors
+ * SPDX-License-Identifier: MIT
+ */
+
+package linux
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestSensors(t *testing.T) {
+	tests := []struct {
+		tree           string
+		cpuTemperature float64
+		fanSpeeds      []int
+		cpuVoltage     float64
+		readings       []string
+	}{
+		{
+			tree: "x86-hybrid",
+			// the package sensor rather than the hottest core
+			cpuTemperature: 45,
+			fanSpeeds:      []int{1250, 0},
+			cpuVoltage:     1.032,
+			// sorted by hwmon number, then by channel type and number
+			readings: []string{
+				"coretemp Package id 0 45 °C",
+				"coretemp Core 0 52 °C",
+				"coretemp Core 8 43 °C",
+				"nct6798 SYSTIN 34 °C",
+				"nct6798 Vcore 1.032 V",
+				"nct6798 in1 1.016 V",
+				"nct6798 fan1 1250 RPM",
+				"nct6798 fan2 0 RPM",
+				// the attributes of an old driver are on the parent device
+				"acpitz temp1 27.8 °C",
+				"nvme Composite 38.85 °C",
+			},
+		},
+		{
+			// no hwmon chips, the temperature of the cpu thermal zone
+			tree:           "arm64-tri-cluster",
+			cpuTemperature: 48.5,
+			fanSpeeds:      []int{},
+			readings:       []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.tree, func(t *testing.T) {
+			setFixtureRoot(t, tt.tree)
+			s := Sensors()
+			if got := s.CpuTemperature(); got != tt.cpuTemperature {
+				t.Errorf("cpu temperature = %v, want %v", got, tt.cpuTemperature)
+			}
+			if got := s.FanSpeeds(); !reflect.DeepEqual(got, tt.fanSpeeds) {
+				t.Errorf("fan speeds = %v, want %v", got, tt.fanSpeeds)
+			}
+			if got := s.CpuVoltage(); got != tt.cpuVoltage {
+				t.Errorf("cpu voltage = %v, want %v", got, tt.cpuVoltage)
+			}
+			readings := make([]string, 0)
+			for _, r := range s.Readings() {
+				readings = append(readings, fmt.Sprintf("%s %s %v %s", r.Chip(), r.Label(), r.Input(), r.Unit()))
+			}
+			if !reflect.DeepEqual(readings, tt.readings) {
+				t.Errorf("readings = %q, want %q", readings, tt.readings)
+			}
+		})
+	}
+}
+
+func TestSensorLimits(t *testing.T) {
+	setFixtureRoot(t, "x86-hybrid")
+	readings := Sensors().Readings()
+	pkg, vcore := readings[0], readings[4]
+	if pkg.Max() != 80 || pkg.Crit() != 100 || !math.IsNaN(pkg.Min()) {
+		t.Errorf("%s limits = %v, %v, %v", pkg.Label(), pkg.Min(), pkg.Max(), pkg.Crit())
+	}
+	if vcore.Min() != 0 || vcore.Max() != 1.744 || !math.IsNaN(vcore.Crit()) {
+		t.Errorf("%s limits = %v, %v, %v", vcore.Label(), vcore.Min(), vcore.Max(), vcore.Crit())
+	}
+}
@@ -0,0 +1,103 @@
+/*
+ * Copyright 2016-2024 The OSHI Project Contributors
+ * SPDX-License-Identifier: MIT
+ */
+
+package linux
+
+import (
+	"fmt"
+	"goshi/sysinfo/hardware"
+	"goshi/util"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+var (
+	// " 0 [PCH            ]: HDA-Intel - HDA Intel PCH"
+	asoundCardRegex = regexp.MustCompile(`^\s*([0-9]+)\s+\[(.*)\]:\s+(.*?)\s+-\s+(.*)$`)
+)
+
+type LinuxSoundCard struct {
+	driverVersion, name, codec string
+}
+
+func (l LinuxSoundCard) DriverVersion() string {
+	return l.driverVersion
+}
+
+func (l LinuxSoundCard) Name() string {
+	return l.name
+}
+
+func (l LinuxSoundCard) Codec() string {
+	return l.codec
+}
+
+// alsaVersion extracts k6.1.0 from "Advanced Linux Sound Architecture Driver Version k6.1.0."
+func alsaVersion() string {
+	version := util.ReadString(filepath.Join(rootPath(procAsound), "version"))
+	if i := strings.LastIndex(version, " "); i >= 0 {
+		version = version[i+1:]
+	}
+	return strings.TrimSuffix(version, ".")
+}
+
+// soundCodecs reads the codec names of a card, only HD Audio cards have codec files
+func soundCodecs(card string) string {
+	files, _ := filepath.Glob(filepath.Join(rootPath(procAsound), "card"+card, "codec#*"))
+	codecs := make([]string, 0, len(files))
+	for _, file := range files {
+		for _, line := range util.ReadLines(file) {
+			if key, value, found := strings.Cut(line, ":"); found && key == "Codec" {
+				codecs = append(codecs, strings.TrimSpace(value))
+				break
+			}
+		}
+	}
+	return util.StringValueOrDefault(strings.Join(codecs, ", "), util.Unknown)
+}
+
+// soundVersionInfo names the kernel driver of the card's pci device along with the alsa version
+func soundVersionInfo(card, version string) string {
+	info := make([]string, 0, 2)
+	device := filepath.Join(rootPath(sysClassSnd), "card"+card, "device")
+	if driverPath, err := os.Readlink(filepath.Join(device, "driver")); err == nil {
+		info = append(info, fmt.Sprintf("Driver=%s", filepath.Base(driverPath)))
+	}
+	if len(version) != 0 {
+		info = append(info, fmt.Sprintf("DriverVersion=%s", version))
+	}
+	return util.StringValueOrDefault(strings.Join(info, ", "), util.Unknown)
+}
+
+// soundCardName appends the ids of the pci device behind the card, which tell apart cards sharing a driver name
+func soundCardName(card, name string) string {
+	device := filepath.Join(rootPath(sysClassSnd), "card"+card, "device")
+	vendor := util.ReadString(filepath.Join(device, "vendor"))
+	deviceId := util.ReadString(filepath.Join(device, "device"))
+	if len(vendor) == 0 || len(deviceId) == 0 {
+		return name
+	}
+	return fmt.Sprintf("%s [%s:%s]", name, parsePCIID(vendor), parsePCIID(deviceId))
+}
+
+func SoundCards() []hardware.SoundCard {
+	version := alsaVersion()
+	cards := make([]hardware.SoundCard, 0)
+	for _, line := range util.ReadLines(filepath.Join(rootPath(procAsound), "cards")) {
+		m := asoundCardRegex.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		card := m[1]
+		cards = append(cards, LinuxSoundCard{
+			driverVersion: soundVersionInfo(card, version),
+			name:          soundCardName(card, m[4]),
+			codec:         soundCodecs(card),
+		})
+	}
+	return cards
+}
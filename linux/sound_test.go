@@ -0,0 +1,72 @@
+/*
+ * Copyright 2016-2024 The OSHI Project Contributors
+ * SPDX-License-Identifier: MIT
+ */
+
+package linux
+
+import (
+	"goshi/sysinfo/hardware"
+	"goshi/util"
+	"reflect"
+	"testing"
+)
+
+func TestSoundCards(t *testing.T) {
+	tests := []struct {
+		tree  string
+		cards []hardware.SoundCard
+	}{
+		{"x86-hybrid", []hardware.SoundCard{
+			LinuxSoundCard{
+				driverVersion: "Driver=snd_hda_intel, DriverVersion=k6.8.0-45-generic",
+				name:          "HDA Intel PCH [0x8086:0x51c8]",
+				// every codec file of the card
+				codec: "Realtek ALC287, Intel Alder Lake-P HDMI",
+			},
+			// a usb card has no pci ids and no codec files
+			LinuxSoundCard{
+				driverVersion: "Driver=snd-usb-audio, DriverVersion=k6.8.0-45-generic",
+				name:          "USB Audio Device",
+				codec:         util.Unknown,
+			},
+		}},
+		{"arm64-tri-cluster", []hardware.SoundCard{
+			LinuxSoundCard{
+				driverVersion: "Driver=sm8450",
+				name:          "waipio-mtp-snd-card",
+				codec:         util.Unknown,
+			},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.tree, func(t *testing.T) {
+			setFixtureRoot(t, tt.tree)
+			if got := SoundCards(); !reflect.DeepEqual(got, tt.cards) {
+				t.Errorf("sound cards = %+v, want %+v", got, tt.cards)
+			}
+		})
+	}
+}
+
+func TestAsoundCardRegex(t *testing.T) {
+	tests := []struct {
+		line string
+		want []string
+	}{
+		{" 0 [PCH            ]: HDA-Intel - HDA Intel PCH", []string{"0", "PCH            ", "HDA-Intel", "HDA Intel PCH"}},
+		// the driver name is cut at 15 characters and may end in a dash
+		{"10 [waipiomtpsndcar]: waipio-mtp-snd- - waipio-mtp-snd-card", []string{"10", "waipiomtpsndcar", "waipio-mtp-snd-", "waipio-mtp-snd-card"}},
+		// the second line of a card
+		{"                      HDA Intel PCH at 0x603f1a0000 irq 147", nil},
+	}
+	for _, tt := range tests {
+		m := asoundCardRegex.FindStringSubmatch(tt.line)
+		if m != nil {
+			m = m[1:]
+		}
+		if !reflect.DeepEqual(m, tt.want) {
+			t.Errorf("%q matches %q, want %q", tt.line, m, tt.want)
+		}
+	}
+}
@@ -0,0 +1,96 @@
+/*
+ * Copyright 2016-2024 The OSHI Project Contributors
+ * SPDX-License-Identifier: MIT
+ */
+
+package linux
+
+import (
+	"fmt"
+	"goshi/sysinfo/hardware"
+	"goshi/util"
+	"goshi/util/smbios"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+type LinuxComputerSystem struct {
+	manufacturer, model, serialNumber, hardwareUUID string
+	firmware                                        hardware.Firmware
+	baseboard                                       hardware.Baseboard
+}
+
+func (l LinuxComputerSystem) Manufacturer() string {
+	return l.manufacturer
+}
+
+func (l LinuxComputerSystem) Model() string {
+	return l.model
+}
+
+func (l LinuxComputerSystem) SerialNumber() string {
+	return l.serialNumber
+}
+
+func (l LinuxComputerSystem) HardwareUUID() string {
+	return l.hardwareUUID
+}
+
+func (l LinuxComputerSystem) Firmware() hardware.Firmware {
+	return l.firmware
+}
+
+func (l LinuxComputerSystem) Baseboard() hardware.Baseboard {
+	return l.baseboard
+}
+
+// readDmiId reads an attribute of /sys/class/dmi/id, the serial numbers and uuid are only readable by root
+func readDmiId(name string) string {
+	return util.ReadString(filepath.Join(rootPath(sysDmiId), name))
+}
+
+// readDeviceTree reads a null terminated device tree property, which describes arm boards without dmi
+func readDeviceTree(path string) string {
+	return strings.TrimSpace(strings.Trim(util.ReadString(rootPath(path)), "\x00"))
+}
+
+func firmware() hardware.Firmware {
+	name := "BIOS"
+	if _, err := os.Stat(rootPath(sysEfi)); err == nil {
+		name = "UEFI"
+	}
+	description := util.Unknown
+	if release := readDmiId("bios_release"); len(release) != 0 {
+		description = fmt.Sprintf("BIOS Revision: %s", release)
+	}
+	return hardware.NewFirmware(
+		util.StringValueOrDefault(readDmiId("bios_vendor"), util.Unknown),
+		name,
+		description,
+		util.StringValueOrDefault(readDmiId("bios_version"), util.Unknown),
+		util.StringValueOrDefault(smbios.FormatDate(readDmiId("bios_date")), util.Unknown),
+	)
+}
+
+func ComputerSystem() hardware.ComputerSystem {
+	baseboard := hardware.NewBaseboard(
+		util.StringValueOrDefault(readDmiId("board_vendor"), util.Unknown),
+		util.StringValueOrDefault(readDmiId("board_name"), util.Unknown),
+		util.StringValueOrDefault(readDmiId("board_version"), util.Unknown),
+		util.StringValueOrDefault(readDmiId("board_serial"), util.Unknown),
+	)
+	model := util.StringValueOrDefault(readDmiId("product_name"), readDeviceTree(procModel))
+	if version := readDmiId("product_version"); len(version) != 0 && len(model) != 0 {
+		model = fmt.Sprintf("%s (version: %s)", model, version)
+	}
+	serial := util.StringValueOrDefault(readDmiId("product_serial"), readDeviceTree(procSerial))
+	return LinuxComputerSystem{
+		manufacturer: util.StringValueOrDefault(readDmiId("sys_vendor"), util.Unknown),
+		model:        util.StringValueOrDefault(model, util.Unknown),
+		serialNumber: util.StringValueOrDefault(serial, baseboard.SerialNumber()),
+		hardwareUUID: util.StringValueOrDefault(readDmiId("product_uuid"), util.Unknown),
+		firmware:     firmware(),
+		baseboard:    baseboard,
+	}
+}
@@ -0,0 +1,71 @@
+/*
+ * Copyright 2016-2024 The OSHI Project Contributors
+ * SPDX-License-Identifier: MIT
+ */
+
+package linux
+
+import (
+	"goshi/sysinfo/hardware"
+	"goshi/util"
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestComputerSystem(t *testing.T) {
+	tests := []struct {
+		tree                                            string
+		manufacturer, model, serialNumber, hardwareUUID string
+		firmware                                        hardware.Firmware
+		baseboard                                       hardware.Baseboard
+	}{
+		{
+			tree:         "x86-hybrid",
+			manufacturer: "LENOVO",
+			model:        "21DCS0XD00 (version: ThinkPad P1 Gen 5)",
+			serialNumber: "PF3XK2LM",
+			hardwareUUID: "4c4c4544-0050-3310-8033-b2c04f4b3933",
+			// the efi directory tells UEFI from BIOS, the date is reformatted
+			firmware:  hardware.NewFirmware("LENOVO", "UEFI", "BIOS Revision: 1.17", "N3MET18W (1.17 )", "2024-03-15"),
+			baseboard: hardware.NewBaseboard("LENOVO", "21DCS0XD00", "SDK0T76479 WIN", "L1HF2AB00CD"),
+		},
+		{
+			// no dmi, the device tree names the board
+			tree:         "arm64-tri-cluster",
+			manufacturer: util.Unknown,
+			model:        "Qualcomm Technologies, Inc. SM8450 QRD",
+			serialNumber: "3a7f21c9",
+			hardwareUUID: util.Unknown,
+			firmware:     hardware.NewFirmware(util.Unknown, "BIOS", util.Unknown, util.Unknown, util.Unknown),
+			baseboard:    hardware.NewBaseboard(util.Unknown, util.Unknown, util.Unknown, util.Unknown),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.tree, func(t *testing.T) {
+			setFixtureRoot(t, tt.tree)
+			cs := ComputerSystem()
+			got := []string{cs.Manufacturer(), cs.Model(), cs.SerialNumber(), cs.HardwareUUID()}
+			if want := []string{tt.manufacturer, tt.model, tt.serialNumber, tt.hardwareUUID}; !reflect.DeepEqual(got, want) {
+				t.Errorf("computer system = %q, want %q", got, want)
+			}
+			if !reflect.DeepEqual(cs.Firmware(), tt.firmware) {
+				t.Errorf("firmware = %+v, want %+v", cs.Firmware(), tt.firmware)
+			}
+			if !reflect.DeepEqual(cs.Baseboard(), tt.baseboard) {
+				t.Errorf("baseboard = %+v, want %+v", cs.Baseboard(), tt.baseboard)
+			}
+		})
+	}
+}
+
+func TestComputerSystemSerialFallback(t *testing.T) {
+	setFixtureRoot(t, "x86-hybrid")
+	// an unreadable product serial falls back to the serial of the baseboard
+	if err := os.Remove(rootPath(sysDmiId + "/product_serial")); err != nil {
+		t.Fatal(err)
+	}
+	if serial := ComputerSystem().SerialNumber(); serial != "L1HF2AB00CD" {
+		t.Errorf("serial = %s, want the baseboard serial", serial)
+	}
+}
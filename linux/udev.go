@@ -0,0 +1,41 @@
+/*
+ * Copyright 2016-2024 The OSHI Project Contributors
+ * SPDX-License-Identifier: MIT
+ */
+
+package linux
+
+import (
+	"fmt"
+	"goshi/util"
+	"path/filepath"
+	"strings"
+)
+
+// udevProperties returns the E: properties udev recorded for a device, empty if udev does not run.
+// Devices are identified as "b8:0" for block devices and "n2" for network interfaces.
+func udevProperties(device string) map[string]string {
+	props := make(map[string]string)
+	path := filepath.Join(rootPath(runUdevData), device)
+	for _, line := range util.ReadLines(path) {
+		key, value, found := strings.Cut(strings.TrimPrefix(line, "E:"), "=")
+		if !found || !strings.HasPrefix(line, "E:") {
+			continue
+		}
+		props[key] = value
+	}
+	return props
+}
+
+func udevBlockDevice(major, minor int) string {
+	return fmt.Sprintf("b%d:%d", major, minor)
+}
+
+// parseDevNumber splits the "major:minor" contents of a sysfs dev file
+func parseDevNumber(dev string) (int, int) {
+	major, minor, found := strings.Cut(strings.TrimSpace(dev), ":")
+	if !found {
+		return 0, 0
+	}
+	return int(util.ParseInt64OrDefault(major, 0)), int(util.ParseInt64OrDefault(minor, 0))
+}
@@ -0,0 +1,116 @@
+/*
+ * Copyright 2016-2024 The OSHI Project Contributors
+ * SPDX-License-Identifier: MIT
+ */
+
+package linux
+
+import (
+	"errors"
+	"fmt"
+	"goshi/internal/ids"
+	"goshi/sysinfo/hardware"
+	"goshi/util"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// usbParent returns the device a usb device is plugged into: 1-1.2 hangs off 1-1, which hangs off the
+// root hub usb1 of its bus
+func usbParent(name string) string {
+	if strings.HasPrefix(name, "usb") {
+		return ""
+	}
+	if i := strings.LastIndex(name, "."); i >= 0 {
+		return name[:i]
+	}
+	bus, _, _ := strings.Cut(name, "-")
+	return "usb" + bus
+}
+
+// usbPorts returns the bus and port numbers of a device name, usb1 is bus 1 without ports
+func usbPorts(name string) []int64 {
+	ports := make([]int64, 0)
+	for _, part := range strings.FieldsFunc(strings.TrimPrefix(name, "usb"), func(r rune) bool {
+		return r == '-' || r == '.'
+	}) {
+		ports = append(ports, util.ParseInt64OrDefault(part, 0))
+	}
+	return ports
+}
+
+func usbPortLess(a, b string) bool {
+	pa, pb := usbPorts(a), usbPorts(b)
+	for i := 0; i < len(pa) && i < len(pb); i++ {
+		if pa[i] != pb[i] {
+			return pa[i] < pb[i]
+		}
+	}
+	return len(pa) < len(pb)
+}
+
+func usbDevice(dir, name string, connectedDevices []hardware.UsbDevice) hardware.UsbDevice {
+	vendorId := strings.ToLower(util.ReadString(filepath.Join(dir, "idVendor")))
+	productId := strings.ToLower(util.ReadString(filepath.Join(dir, "idProduct")))
+	serial := util.ReadString(filepath.Join(dir, "serial"))
+	// descriptor strings are optional, cheap devices often have none
+	vendor := util.StringValueOrDefault(util.ReadString(filepath.Join(dir, "manufacturer")), ids.UsbVendor(vendorId))
+	product := util.StringValueOrDefault(util.ReadString(filepath.Join(dir, "product")), ids.UsbProduct(vendorId, productId))
+	if len(product) == 0 {
+		product = fmt.Sprintf("%s:%s", vendorId, productId)
+	}
+	return hardware.NewUsbDevice(
+		product,
+		util.StringValueOrDefault(vendor, util.Unknown),
+		vendorId,
+		productId,
+		serial,
+		util.StringValueOrDefault(serial, name),
+		connectedDevices,
+	)
+}
+
+// UsbDevices lists the usb devices, as a tree below the root hubs of every controller if tree is set.
+func UsbDevices(tree bool) ([]hardware.UsbDevice, error) {
+	devicesDir := rootPath(sysBusUsb)
+	entries, err := os.ReadDir(devicesDir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return make([]hardware.UsbDevice, 0), nil
+	} else if err != nil {
+		return nil, fmt.Errorf("usb: failed to read %s: %w", devicesDir, err)
+	}
+	names := make([]string, 0)
+	for _, entry := range entries {
+		// 1-1:1.0 are the interfaces of device 1-1
+		if !strings.Contains(entry.Name(), ":") {
+			names = append(names, entry.Name())
+		}
+	}
+	sort.Slice(names, func(i, j int) bool {
+		return usbPortLess(names[i], names[j])
+	})
+	if !tree {
+		devices := make([]hardware.UsbDevice, 0, len(names))
+		for _, name := range names {
+			devices = append(devices, usbDevice(filepath.Join(devicesDir, name), name, make([]hardware.UsbDevice, 0)))
+		}
+		return devices, nil
+	}
+	children := make(map[string][]string)
+	for _, name := range names {
+		parent := usbParent(name)
+		children[parent] = append(children[parent], name)
+	}
+	var build func(parent string) []hardware.UsbDevice
+	build = func(parent string) []hardware.UsbDevice {
+		devices := make([]hardware.UsbDevice, 0, len(children[parent]))
+		for _, name := range children[parent] {
+			devices = append(devices, usbDevice(filepath.Join(devicesDir, name), name, build(name)))
+		}
+		return devices
+	}
+	return build(""), nil
+}
@@ -0,0 +1,124 @@
+/*
+ * Copyright 2016-2024 The OSHI Project Contributors
+ * SPDX-License-Identifier: MIT
+ */
+
+package linux
+
+import (
+	"goshi/sysinfo/hardware"
+	"goshi/util"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestUsbParent(t *testing.T) {
+	tests := map[string]string{
+		"usb1":    "",
+		"1-4":     "usb1",
+		"1-4.2":   "1-4",
+		"1-4.2.3": "1-4.2",
+		"2-10":    "usb2",
+		"10-1":    "usb10",
+	}
+	for name, want := range tests {
+		if got := usbParent(name); got != want {
+			t.Errorf("usbParent(%s) = %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestUsbPorts(t *testing.T) {
+	tests := map[string][]int64{
+		"usb1":    {1},
+		"1-4":     {1, 4},
+		"1-4.2.3": {1, 4, 2, 3},
+		"2-10":    {2, 10},
+	}
+	for name, want := range tests {
+		if got := usbPorts(name); !reflect.DeepEqual(got, want) {
+			t.Errorf("usbPorts(%s) = %v, want %v", name, got, want)
+		}
+	}
+}
+
+func TestUsbPortLess(t *testing.T) {
+	// root hubs before their devices, hubs before the devices behind them, ports by number
+	want := []string{"usb1", "1-2", "1-4", "1-4.1", "1-4.2", "1-4.10", "1-10", "usb2", "2-1", "usb10"}
+	got := []string{"1-10", "usb10", "1-4.10", "2-1", "1-4.2", "usb2", "1-4", "usb1", "1-4.1", "1-2"}
+	sort.Slice(got, func(i, j int) bool {
+		return usbPortLess(got[i], got[j])
+	})
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("sorted = %v, want %v", got, want)
+	}
+}
+
+func TestUsbDevices(t *testing.T) {
+	setFixtureRoot(t, "x86-hybrid")
+	none := make([]hardware.UsbDevice, 0)
+	rootHub := "Linux 6.8.0-45-generic xhci-hcd"
+	// the interfaces 1-4:1.0 and 2-1:1.0 are skipped
+	want := []hardware.UsbDevice{
+		hardware.NewUsbDevice("xHCI Host Controller", rootHub, "1d6b", "0002", "0000:00:14.0", "0000:00:14.0", none),
+		// without descriptor strings the names come from the usb database, the ids are lower case
+		hardware.NewUsbDevice("Hub", "Genesys Logic, Inc.", "05e3", "0608", "", "1-4", none),
+		hardware.NewUsbDevice("USB Receiver", "Logitech", "046d", "c52b", "", "1-4.1", none),
+		hardware.NewUsbDevice("1a2b:3c4d", util.Unknown, "1a2b", "3c4d", "", "1-4.2", none),
+		hardware.NewUsbDevice("8087:0033", "Intel Corp.", "8087", "0033", "", "1-10", none),
+		hardware.NewUsbDevice("xHCI Host Controller", rootHub, "1d6b", "0003", "0000:00:14.0", "0000:00:14.0", none),
+		hardware.NewUsbDevice("SanDisk 3.2Gen1", "USB", "0781", "5583", "4C530001234567891234", "4C530001234567891234", none),
+	}
+	devices, err := UsbDevices(false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(devices, want) {
+		t.Errorf("devices = %+v, want %+v", devices, want)
+	}
+}
+
+// usbTree lists the unique ids of devices and the devices connected to them, indented by depth
+func usbTree(devices []hardware.UsbDevice, indent string) []string {
+	lines := make([]string, 0)
+	for _, d := range devices {
+		lines = append(lines, indent+d.UniqueDeviceId())
+		lines = append(lines, usbTree(d.ConnectedDevices(), indent+"  ")...)
+	}
+	return lines
+}
+
+func TestUsbDeviceTree(t *testing.T) {
+	tests := []struct {
+		tree string
+		want []string
+	}{
+		{"x86-hybrid", []string{
+			"0000:00:14.0",
+			"  1-4",
+			"    1-4.1",
+			"    1-4.2",
+			"  1-10",
+			"0000:00:14.0",
+			"  4C530001234567891234",
+		}},
+		{"arm64-tri-cluster", []string{
+			"xhci-hcd.1.auto",
+			"  28201FDH2004TL",
+			"xhci-hcd.1.auto",
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.tree, func(t *testing.T) {
+			setFixtureRoot(t, tt.tree)
+			devices, err := UsbDevices(true)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := usbTree(devices, ""); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("tree = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
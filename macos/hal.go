@@ -0,0 +1,63 @@
+package macos
+
+import (
+	"errors"
+	"goshi/sysinfo/hardware"
+)
+
+var errNotImplemented = errors.New("not implemented")
+
+type MacHardwareAbstractionLayer struct {
+}
+
+func (m MacHardwareAbstractionLayer) ComputerSystem() (hardware.ComputerSystem, error) {
+	return nil, errNotImplemented
+}
+
+func (m MacHardwareAbstractionLayer) Processor() (hardware.CentralProcessor, error) {
+	return Processor()
+}
+
+func (m MacHardwareAbstractionLayer) Memory() (hardware.GlobalMemory, error) {
+	return nil, errNotImplemented
+}
+
+func (m MacHardwareAbstractionLayer) GraphicsCards() ([]hardware.GraphicsCard, error) {
+	return nil, errNotImplemented
+}
+
+func (m MacHardwareAbstractionLayer) PciDevices() ([]hardware.PciDevice, error) {
+	return nil, errNotImplemented
+}
+
+func (m MacHardwareAbstractionLayer) DiskStores() ([]hardware.HWDiskStore, error) {
+	return nil, errNotImplemented
+}
+
+func (m MacHardwareAbstractionLayer) NetworkIFs(includeLocal bool) ([]hardware.NetworkIF, error) {
+	return nil, errNotImplemented
+}
+
+func (m MacHardwareAbstractionLayer) Sensors() (hardware.Sensors, error) {
+	return nil, errNotImplemented
+}
+
+func (m MacHardwareAbstractionLayer) PowerSources() ([]hardware.PowerSource, error) {
+	return nil, errNotImplemented
+}
+
+func (m MacHardwareAbstractionLayer) UsbDevices(tree bool) ([]hardware.UsbDevice, error) {
+	return nil, errNotImplemented
+}
+
+func (m MacHardwareAbstractionLayer) SoundCards() ([]hardware.SoundCard, error) {
+	return nil, errNotImplemented
+}
+
+func (m MacHardwareAbstractionLayer) Displays() ([]hardware.Display, error) {
+	return nil, errNotImplemented
+}
+
+func HardwareAbstractionLayer() hardware.HardwareAbstractionLayer {
+	return MacHardwareAbstractionLayer{}
+}
@@ -0,0 +1,52 @@
+package macos
+
+import (
+	"goshi/sysinfo/software"
+	"goshi/util"
+	"os"
+	"regexp"
+)
+
+const (
+	systemVersionPlist = "/System/Library/CoreServices/SystemVersion.plist"
+)
+
+var (
+	plistStringRegex = regexp.MustCompile(`<key>(\w+)</key>\s*<string>([^<]*)</string>`)
+)
+
+type MacOperatingSystem struct {
+	versionInfo software.OSVersionInfo
+}
+
+func (m MacOperatingSystem) Family() string {
+	return "macOS"
+}
+
+func (m MacOperatingSystem) Manufacturer() string {
+	return "Apple"
+}
+
+func (m MacOperatingSystem) VersionInfo() software.OSVersionInfo {
+	return m.versionInfo
+}
+
+func (m MacOperatingSystem) Bitness() int {
+	return util.Bits
+}
+
+func OperatingSystem() software.OperatingSystem {
+	values := make(map[string]string)
+	if b, err := os.ReadFile(systemVersionPlist); err == nil {
+		for _, match := range plistStringRegex.FindAllStringSubmatch(string(b), -1) {
+			values[match[1]] = match[2]
+		}
+	}
+	return MacOperatingSystem{
+		versionInfo: software.NewOSVersionInfo(
+			util.StringValueOrDefault(values["ProductVersion"], util.Unknown),
+			"",
+			values["ProductBuildVersion"],
+		),
+	}
+}
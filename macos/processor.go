@@ -1,9 +1,9 @@
 package macos
 
 import (
-	"errors"
+	"goshi/sysinfo/hardware"
 )
 
-func Processor() (internal.CentralProcessor, error) {
-	return internal.CentralProcessor{}, errors.New("not implemented")
+func Processor() (hardware.CentralProcessor, error) {
+	return nil, errNotImplemented
 }
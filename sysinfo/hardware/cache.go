@@ -0,0 +1,67 @@
+/*
+ * Copyright 2016-2024 The OSHI Project Contributors
+ * SPDX-License-Identifier: MIT
+ */
+
+package hardware
+
+type CacheType int
+
+const (
+	CacheUnified CacheType = iota
+	CacheInstruction
+	CacheData
+	CacheTrace
+)
+
+func (c CacheType) String() string {
+	switch c {
+	case CacheUnified:
+		return "Unified"
+	case CacheInstruction:
+		return "Instruction"
+	case CacheData:
+		return "Data"
+	case CacheTrace:
+		return "Trace"
+	default:
+		return "Unknown"
+	}
+}
+
+type ProcessorCache struct {
+	level, associativity, lineSize int
+	cacheSize                      int64
+	cacheType                      CacheType
+}
+
+func (p ProcessorCache) Level() int {
+	return p.level
+}
+
+// Associativity is the number of ways of the cache, 0 if unknown and 0xFF if fully associative.
+func (p ProcessorCache) Associativity() int {
+	return p.associativity
+}
+
+func (p ProcessorCache) LineSize() int {
+	return p.lineSize
+}
+
+func (p ProcessorCache) CacheSize() int64 {
+	return p.cacheSize
+}
+
+func (p ProcessorCache) Type() CacheType {
+	return p.cacheType
+}
+
+func NewProcessorCache(level, associativity, lineSize int, cacheSize int64, cacheType CacheType) ProcessorCache {
+	return ProcessorCache{
+		level:         level,
+		associativity: associativity,
+		lineSize:      lineSize,
+		cacheSize:     cacheSize,
+		cacheType:     cacheType,
+	}
+}
@@ -0,0 +1,82 @@
+/*
+ * Copyright 2016-2024 The OSHI Project Contributors
+ * SPDX-License-Identifier: MIT
+ */
+
+package hardware
+
+// HWDiskStore is a physical storage device with its cumulative I/O counters.
+type HWDiskStore interface {
+	Name() string
+	Model() string
+	Serial() string
+	// Size returns the capacity of the disk in bytes.
+	Size() int64
+	Reads() int64
+	ReadBytes() int64
+	Writes() int64
+	WriteBytes() int64
+	CurrentQueueLength() int64
+	// TransferTime returns the time spent doing I/O, in milliseconds.
+	TransferTime() int64
+	Partitions() []HWPartition
+	// TimeStamp returns the time the counters were read, in milliseconds since the epoch.
+	TimeStamp() int64
+	// UpdateAttributes reads the counters again, it returns false if the disk is gone.
+	UpdateAttributes() bool
+}
+
+type HWPartition struct {
+	identification, name, typ, uuid, mountPoint string
+	size                                        int64
+	major, minor                                int
+}
+
+// Identification is the device node of the partition, such as /dev/sda1.
+func (p HWPartition) Identification() string {
+	return p.identification
+}
+
+func (p HWPartition) Name() string {
+	return p.name
+}
+
+// Type is the file system type of the partition, such as ext4 or swap.
+func (p HWPartition) Type() string {
+	return p.typ
+}
+
+func (p HWPartition) UUID() string {
+	return p.uuid
+}
+
+// Size returns the size of the partition in bytes.
+func (p HWPartition) Size() int64 {
+	return p.size
+}
+
+func (p HWPartition) Major() int {
+	return p.major
+}
+
+func (p HWPartition) Minor() int {
+	return p.minor
+}
+
+// MountPoint is where the partition is mounted, empty if it is not.
+func (p HWPartition) MountPoint() string {
+	return p.mountPoint
+}
+
+func NewHWPartition(identification, name, typ, uuid string, size int64, major, minor int, mountPoint string) HWPartition {
+	return HWPartition{
+		identification: identification,
+		name:           name,
+		typ:            typ,
+		uuid:           uuid,
+		size:           size,
+		major:          major,
+		minor:          minor,
+		mountPoint:     mountPoint,
+	}
+}
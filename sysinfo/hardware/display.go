@@ -0,0 +1,19 @@
+/*
+ * Copyright 2016-2024 The OSHI Project Contributors
+ * SPDX-License-Identifier: MIT
+ */
+
+package hardware
+
+// Display is a connected monitor, its EDID can be decoded with the util/edid package.
+type Display struct {
+	edid []byte
+}
+
+func (d Display) Edid() []byte {
+	return d.edid
+}
+
+func NewDisplay(edid []byte) Display {
+	return Display{edid: edid}
+}
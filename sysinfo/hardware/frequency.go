@@ -0,0 +1,77 @@
+/*
+ * Copyright 2016-2024 The OSHI Project Contributors
+ * SPDX-License-Identifier: MIT
+ */
+
+package hardware
+
+// FrequencyPolicy describes how the frequency of a group of logical processors is scaled.
+// Frequencies are in Hz, and negative when unknown.
+type FrequencyPolicy struct {
+	name, driver, governor                           string
+	processors                                       []int
+	minFreq, maxFreq, scalingMinFreq, scalingMaxFreq int64
+	boost                                            int
+}
+
+func (f FrequencyPolicy) Name() string {
+	return f.name
+}
+
+func (f FrequencyPolicy) Driver() string {
+	return f.driver
+}
+
+func (f FrequencyPolicy) Governor() string {
+	return f.governor
+}
+
+// Processors returns the numbers of the logical processors the policy applies to.
+func (f FrequencyPolicy) Processors() []int {
+	return f.processors
+}
+
+// MinFreq is the lowest frequency the hardware supports.
+func (f FrequencyPolicy) MinFreq() int64 {
+	return f.minFreq
+}
+
+// MaxFreq is the highest frequency the hardware supports.
+func (f FrequencyPolicy) MaxFreq() int64 {
+	return f.maxFreq
+}
+
+// ScalingMinFreq is the lowest frequency the governor may select.
+func (f FrequencyPolicy) ScalingMinFreq() int64 {
+	return f.scalingMinFreq
+}
+
+// ScalingMaxFreq is the highest frequency the governor may select.
+func (f FrequencyPolicy) ScalingMaxFreq() int64 {
+	return f.scalingMaxFreq
+}
+
+// Boost reports whether boost (turbo) frequencies are enabled, ok is false when it cannot be determined.
+func (f FrequencyPolicy) Boost() (enabled bool, ok bool) {
+	return f.boost > 0, f.boost >= 0
+}
+
+// NewFrequencyPolicy creates a policy, boost is 1 when enabled, 0 when disabled and -1 when unknown.
+func NewFrequencyPolicy(
+	name, driver, governor string,
+	processors []int,
+	minFreq, maxFreq, scalingMinFreq, scalingMaxFreq int64,
+	boost int,
+) FrequencyPolicy {
+	return FrequencyPolicy{
+		name:           name,
+		driver:         driver,
+		governor:       governor,
+		processors:     processors,
+		minFreq:        minFreq,
+		maxFreq:        maxFreq,
+		scalingMinFreq: scalingMinFreq,
+		scalingMaxFreq: scalingMaxFreq,
+		boost:          boost,
+	}
+}
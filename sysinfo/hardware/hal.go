@@ -0,0 +1,24 @@
+/*
+ * Copyright 2016-2024 The OSHI Project Contributors
+ * SPDX-License-Identifier: MIT
+ */
+
+package hardware
+
+// HardwareAbstractionLayer gives access to the hardware of the platform the program runs on.
+type HardwareAbstractionLayer interface {
+	ComputerSystem() (ComputerSystem, error)
+	Processor() (CentralProcessor, error)
+	Memory() (GlobalMemory, error)
+	GraphicsCards() ([]GraphicsCard, error)
+	PciDevices() ([]PciDevice, error)
+	DiskStores() ([]HWDiskStore, error)
+	// NetworkIFs lists the network interfaces, including the loopback interface if includeLocal is set.
+	NetworkIFs(includeLocal bool) ([]NetworkIF, error)
+	Sensors() (Sensors, error)
+	PowerSources() ([]PowerSource, error)
+	// UsbDevices lists the usb devices, as a tree below the usb controllers if tree is set.
+	UsbDevices(tree bool) ([]UsbDevice, error)
+	SoundCards() ([]SoundCard, error)
+	Displays() ([]Display, error)
+}
@@ -5,6 +5,22 @@
 
 package hardware
 
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"goshi/util"
+	"strconv"
+)
+
+var (
+	//go:embed memoryTypes.json
+	memoryTypes []byte
+
+	memory map[uint16]string
+	smBios map[uint32]string
+)
+
 type PhysicalMemory struct {
 	bankLabel, manufacturer, memoryType, partNumber, serialNumber string
 	capacity, clockSpeed                                          int64
@@ -67,5 +83,53 @@ type GlobalMemory interface {
 	Available() int64
 	PageSize() int64
 	VirtualMemory() VirtualMemory
-	PhysicalMemory() []PhysicalMemory
+	PhysicalMemory() ([]PhysicalMemory, error)
+}
+
+// MemoryType returns the name of a Win32_PhysicalMemory MemoryType value.
+func MemoryType(code uint16) string {
+	if val, exists := memory[code]; exists {
+		return val
+	}
+	return util.Unknown
+}
+
+// SMBiosMemoryType returns the name of an SMBIOS memory device type, as found in
+// Win32_PhysicalMemory.SMBiosMemoryType and the type field of SMBIOS type 17 records.
+func SMBiosMemoryType(code uint32) string {
+	if val, exists := smBios[code]; exists {
+		return val
+	}
+	return util.Unknown
+}
+
+func parseMemoryTypes[T uint16 | uint32](d map[string]string, bits int) (map[T]string, error) {
+	res := make(map[T]string)
+	for k, v := range d {
+		i, err := strconv.ParseUint(k, 10, bits)
+		if err != nil {
+			return nil, err
+		}
+		res[T(i)] = v
+	}
+	return res, nil
+}
+
+func init() {
+	var data map[string]map[string]string
+	err := json.Unmarshal(memoryTypes, &data)
+	if err != nil {
+		err = fmt.Errorf("memory: error unmarshalling memory types: %w", err)
+		panic(err)
+	}
+	memory, err = parseMemoryTypes[uint16](data["memory"], 16)
+	if err != nil {
+		err = fmt.Errorf("memory: error parsing memory hardware: %w", err)
+		panic(err)
+	}
+	smBios, err = parseMemoryTypes[uint32](data["smBios"], 32)
+	if err != nil {
+		err = fmt.Errorf("memory: error parsing smBios hardware: %w", err)
+		panic(err)
+	}
 }
@@ -0,0 +1,70 @@
+/*
+ * Copyright 2016-2024 The OSHI Project Contributors
+ * SPDX-License-Identifier: MIT
+ */
+
+package hardware
+
+// IfOperStatus is the operational state of a network interface, numbered as ifOperStatus of RFC 2863.
+type IfOperStatus int
+
+const (
+	IfUp IfOperStatus = iota + 1
+	IfDown
+	IfTesting
+	IfUnknown
+	IfDormant
+	IfNotPresent
+	IfLowerLayerDown
+)
+
+func (s IfOperStatus) String() string {
+	switch s {
+	case IfUp:
+		return "Up"
+	case IfDown:
+		return "Down"
+	case IfTesting:
+		return "Testing"
+	case IfDormant:
+		return "Dormant"
+	case IfNotPresent:
+		return "NotPresent"
+	case IfLowerLayerDown:
+		return "LowerLayerDown"
+	default:
+		return "Unknown"
+	}
+}
+
+// NetworkIF is a network interface with its addresses and cumulative traffic counters.
+type NetworkIF interface {
+	Name() string
+	DisplayName() string
+	Index() int
+	MTU() int
+	MacAddr() string
+	IPv4Addr() []string
+	// SubnetMasks returns the prefix length of every IPv4 address.
+	SubnetMasks() []int
+	IPv6Addr() []string
+	// PrefixLengths returns the prefix length of every IPv6 address.
+	PrefixLengths() []int
+	// Speed returns the link speed in bits per second, 0 if unknown.
+	Speed() int64
+	IfOperStatus() IfOperStatus
+	// Virtual reports whether the interface has no physical device behind it.
+	Virtual() bool
+	BytesRecv() int64
+	BytesSent() int64
+	PacketsRecv() int64
+	PacketsSent() int64
+	InErrors() int64
+	OutErrors() int64
+	InDrops() int64
+	Collisions() int64
+	// TimeStamp returns the time the counters were read, in milliseconds since the epoch.
+	TimeStamp() int64
+	// UpdateAttributes reads the counters, speed and status again, it returns false if the interface is gone.
+	UpdateAttributes() bool
+}
@@ -0,0 +1,99 @@
+/*
+ * Copyright 2016-2024 The OSHI Project Contributors
+ * SPDX-License-Identifier: MIT
+ */
+
+package hardware
+
+// PciDevice is a function of a device on the pci bus. Ids are hexadecimal strings such as 0x10de,
+// names are resolved from the pci.ids database and fall back to the ids.
+type PciDevice struct {
+	address, classId, className, vendorId, vendor, deviceId, name string
+	subsystemVendorId, subsystemDeviceId, subsystemName, revision string
+	driver                                                        string
+	iommuGroup, numaNode                                          int
+}
+
+// Address is the domain, bus, device and function of the device, such as 0000:00:02.0.
+func (p PciDevice) Address() string {
+	return p.address
+}
+
+// ClassId is the class, subclass and programming interface code, such as 0x030000.
+func (p PciDevice) ClassId() string {
+	return p.classId
+}
+
+func (p PciDevice) ClassName() string {
+	return p.className
+}
+
+func (p PciDevice) VendorId() string {
+	return p.vendorId
+}
+
+func (p PciDevice) Vendor() string {
+	return p.vendor
+}
+
+func (p PciDevice) DeviceId() string {
+	return p.deviceId
+}
+
+func (p PciDevice) Name() string {
+	return p.name
+}
+
+func (p PciDevice) SubsystemVendorId() string {
+	return p.subsystemVendorId
+}
+
+func (p PciDevice) SubsystemDeviceId() string {
+	return p.subsystemDeviceId
+}
+
+func (p PciDevice) SubsystemName() string {
+	return p.subsystemName
+}
+
+func (p PciDevice) Revision() string {
+	return p.revision
+}
+
+// Driver is the kernel driver bound to the device, empty if there is none.
+func (p PciDevice) Driver() string {
+	return p.driver
+}
+
+// IommuGroup returns the iommu group of the device, -1 if the iommu is disabled.
+func (p PciDevice) IommuGroup() int {
+	return p.iommuGroup
+}
+
+// NumaNode returns the numa node the device is attached to, -1 if unknown.
+func (p PciDevice) NumaNode() int {
+	return p.numaNode
+}
+
+func NewPciDevice(
+	address, classId, className, vendorId, vendor, deviceId, name string,
+	subsystemVendorId, subsystemDeviceId, subsystemName, revision, driver string,
+	iommuGroup, numaNode int,
+) PciDevice {
+	return PciDevice{
+		address:           address,
+		classId:           classId,
+		className:         className,
+		vendorId:          vendorId,
+		vendor:            vendor,
+		deviceId:          deviceId,
+		name:              name,
+		subsystemVendorId: subsystemVendorId,
+		subsystemDeviceId: subsystemDeviceId,
+		subsystemName:     subsystemName,
+		revision:          revision,
+		driver:            driver,
+		iommuGroup:        iommuGroup,
+		numaNode:          numaNode,
+	}
+}
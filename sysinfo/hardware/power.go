@@ -0,0 +1,68 @@
+/*
+ * Copyright 2016-2024 The OSHI Project Contributors
+ * SPDX-License-Identifier: MIT
+ */
+
+package hardware
+
+// CapacityUnits is the unit of the capacities of a PowerSource.
+type CapacityUnits int
+
+const (
+	// CapacityMWh is milliwatt-hours.
+	CapacityMWh CapacityUnits = iota
+	// CapacityMAh is milliamp-hours.
+	CapacityMAh
+	// CapacityRelative is a percentage of the maximum capacity.
+	CapacityRelative
+)
+
+func (c CapacityUnits) String() string {
+	switch c {
+	case CapacityMWh:
+		return "mWh"
+	case CapacityMAh:
+		return "mAh"
+	default:
+		return "%"
+	}
+}
+
+const (
+	// TimeRemainingUnknown is the estimate of a battery still calculating its discharge rate.
+	TimeRemainingUnknown = -1
+	// TimeRemainingUnlimited is the estimate while running on external power.
+	TimeRemainingUnlimited = -2
+)
+
+// PowerSource is a battery of the system.
+type PowerSource interface {
+	Name() string
+	DeviceName() string
+	// RemainingCapacityPercent returns the charge as a fraction between 0 and 1.
+	RemainingCapacityPercent() float64
+	// TimeRemainingEstimated returns the seconds until the battery is empty, or one of the
+	// TimeRemainingUnknown and TimeRemainingUnlimited values.
+	TimeRemainingEstimated() float64
+	// PowerUsageRate returns the power in milliwatts, negative while discharging.
+	PowerUsageRate() float64
+	// Voltage returns the voltage in volts, -1 if unknown.
+	Voltage() float64
+	// Amperage returns the current in milliamps, negative while discharging.
+	Amperage() float64
+	PowerOnLine() bool
+	Charging() bool
+	Discharging() bool
+	CapacityUnits() CapacityUnits
+	// CurrentCapacity, MaxCapacity and DesignCapacity are in CapacityUnits, -1 if unknown.
+	CurrentCapacity() int64
+	MaxCapacity() int64
+	DesignCapacity() int64
+	// CycleCount returns the charge cycles of the battery, -1 if unknown.
+	CycleCount() int
+	Chemistry() string
+	Manufacturer() string
+	SerialNumber() string
+	// UpdateAttributes reads the state of the battery again, it returns false if the battery is gone.
+	UpdateAttributes() bool
+}
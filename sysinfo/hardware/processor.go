@@ -10,7 +10,9 @@ import (
 	"fmt"
 	"goshi/util"
 	"regexp"
+	"slices"
 	"strings"
+	"time"
 )
 
 var (
@@ -18,6 +20,14 @@ var (
 	propsAsBytes []byte
 	archPops     = make(map[string]string)
 	freqRegex    = regexp.MustCompile("@ (.*)$")
+
+	// names that differ between platforms for the same feature
+	featureAliases = map[string]string{
+		"pni":    "sse3",
+		"asimd":  "neon",
+		"sse4.1": "sse4_1",
+		"sse4.2": "sse4_2",
+	}
 )
 
 func populateProperties() {
@@ -54,6 +64,7 @@ type ProcessorIdentifier struct {
 	vendor, name, family, model, stepping, processorID, identifier, microarchitecture string
 	is64bit                                                                           bool
 	frequency                                                                         int64
+	features                                                                          []string
 }
 
 func (procId *ProcessorIdentifier) queryMicroarchitecture() string {
@@ -135,11 +146,46 @@ func (procId *ProcessorIdentifier) Frequency() int64 {
 	return procId.frequency
 }
 
+// Features returns the sorted instruction set features of the processor, such as avx2, sse4_2, neon or sve.
+// Names are the lower case flags of /proc/cpuinfo, except that a feature the platforms name differently
+// has one name: pni is sse3, asimd is neon and sse4.1 and sse4.2 are sse4_1 and sse4_2.
+func (procId *ProcessorIdentifier) Features() []string {
+	return procId.features
+}
+
+func (procId *ProcessorIdentifier) HasFeature(feature string) bool {
+	_, found := slices.BinarySearch(procId.features, normalizeFeature(feature))
+	return found
+}
+
+func normalizeFeature(feature string) string {
+	feature = strings.ToLower(strings.TrimSpace(feature))
+	if alias, exists := featureAliases[feature]; exists {
+		return alias
+	}
+	return feature
+}
+
+func normalizeFeatures(features []string) []string {
+	res := make([]string, 0, len(features))
+	for _, feature := range features {
+		if feature = normalizeFeature(feature); len(feature) != 0 {
+			res = append(res, feature)
+		}
+	}
+	slices.Sort(res)
+	return slices.Compact(res)
+}
+
 func NewProcessorIdentifier(
 	vendor, name, family, model, stepping, processorID string,
 	is64bit bool,
 	frequency int64,
+	features []string,
 ) ProcessorIdentifier {
+	if strings.HasPrefix(vendor, "0x") {
+		vendor = queryVendorFromImplementer(vendor)
+	}
 	var identifier string
 	if vendor == "GenuineIntel" {
 		if is64bit {
@@ -169,10 +215,102 @@ func NewProcessorIdentifier(
 		identifier:  identifier,
 		is64bit:     is64bit,
 		frequency:   frequency,
+		features:    normalizeFeatures(features),
 	}
 	return proc
 }
 
+type LogicalProcessor struct {
+	processorNumber, physicalProcessorNumber, physicalPackageNumber, numaNode, processorGroup int
+}
+
+func (l LogicalProcessor) ProcessorNumber() int {
+	return l.processorNumber
+}
+
+func (l LogicalProcessor) PhysicalProcessorNumber() int {
+	return l.physicalProcessorNumber
+}
+
+func (l LogicalProcessor) PhysicalPackageNumber() int {
+	return l.physicalPackageNumber
+}
+
+func (l LogicalProcessor) NumaNode() int {
+	return l.numaNode
+}
+
+func (l LogicalProcessor) ProcessorGroup() int {
+	return l.processorGroup
+}
+
+func NewLogicalProcessor(
+	processorNumber, physicalProcessorNumber, physicalPackageNumber, numaNode, processorGroup int,
+) LogicalProcessor {
+	return LogicalProcessor{
+		processorNumber:         processorNumber,
+		physicalProcessorNumber: physicalProcessorNumber,
+		physicalPackageNumber:   physicalPackageNumber,
+		numaNode:                numaNode,
+		processorGroup:          processorGroup,
+	}
+}
+
+type PhysicalProcessor struct {
+	physicalPackageNumber, physicalProcessorNumber, efficiency int
+	idString                                                   string
+}
+
+func (p PhysicalProcessor) PhysicalPackageNumber() int {
+	return p.physicalPackageNumber
+}
+
+func (p PhysicalProcessor) PhysicalProcessorNumber() int {
+	return p.physicalProcessorNumber
+}
+
+// Efficiency is the relative efficiency class of the core, higher values are more performant
+// and less efficient. Cores of a non-hybrid processor all have the same class.
+func (p PhysicalProcessor) Efficiency() int {
+	return p.efficiency
+}
+
+func (p PhysicalProcessor) IdString() string {
+	return p.idString
+}
+
+func NewPhysicalProcessor(physicalPackageNumber, physicalProcessorNumber, efficiency int, idString string) PhysicalProcessor {
+	return PhysicalProcessor{
+		physicalPackageNumber:   physicalPackageNumber,
+		physicalProcessorNumber: physicalProcessorNumber,
+		efficiency:              efficiency,
+		idString:                idString,
+	}
+}
+
+// CoresByEfficiency splits procs into the cores of the lowest efficiency class and all others, so
+// that the middle tier of a processor with prime, big and little cores counts as performance cores.
+// Every core of a non-hybrid processor is a performance core.
+func CoresByEfficiency(procs []PhysicalProcessor) (performance, efficiency []PhysicalProcessor) {
+	lowest, highest := 0, 0
+	for i, p := range procs {
+		if i == 0 {
+			lowest, highest = p.efficiency, p.efficiency
+		}
+		lowest, highest = min(lowest, p.efficiency), max(highest, p.efficiency)
+	}
+	performance = make([]PhysicalProcessor, 0, len(procs))
+	efficiency = make([]PhysicalProcessor, 0)
+	for _, p := range procs {
+		if p.efficiency == lowest && lowest != highest {
+			efficiency = append(efficiency, p)
+		} else {
+			performance = append(performance, p)
+		}
+	}
+	return performance, efficiency
+}
+
 type ProcOption func(processor *CentralProcessor)
 
 type CentralProcessor interface {
@@ -180,6 +318,27 @@ type CentralProcessor interface {
 	PhysicalPackageCount() int
 	PhysicalProcessorCount() int
 	LogicalProcessorCount() int
+	LogicalProcessors() []LogicalProcessor
+	PhysicalProcessors() []PhysicalProcessor
+	// CoresByEfficiency returns the performance cores and, on hybrid processors, the efficiency cores.
+	CoresByEfficiency() (performance, efficiency []PhysicalProcessor)
+	// ProcessorCaches returns every cache of the processor, a cache shared by several cores is reported once.
+	ProcessorCaches() []ProcessorCache
+	// MaxFreq returns the highest frequency of the processor in Hz, -1 if unknown.
+	MaxFreq() int64
+	// CurrentFreq returns the frequency of every logical processor in Hz, -1 where unknown.
+	CurrentFreq() []int64
+	// FrequencyPolicies returns the frequency scaling policies, empty where the platform has none.
+	FrequencyPolicies() []FrequencyPolicy
+	SystemCpuLoadTicks() []int64
+	ProcessorCpuLoadTicks() [][]int64
+	SystemCpuLoadBetweenTicks(oldTicks []int64) float64
+	ProcessorCpuLoadBetweenTicks(oldTicks [][]int64) []float64
+	SystemCpuLoad(delay time.Duration) float64
+	ProcessorCpuLoad(delay time.Duration) []float64
+	// SystemLoadAverage returns the 1, 5 and 15 minute load averages, up to nelem of them. nelem is
+	// clamped between 1 and 3. Averages that are not available are negative.
+	SystemLoadAverage(nelem int) []float64
 }
 
 func init() {
@@ -0,0 +1,63 @@
+/*
+ * Copyright 2016-2024 The OSHI Project Contributors
+ * SPDX-License-Identifier: MIT
+ */
+
+package hardware
+
+// SensorReading is one channel of a hardware monitoring chip. Limits the chip does not report are NaN.
+type SensorReading struct {
+	chip, label, unit     string
+	input, min, max, crit float64
+}
+
+func (s SensorReading) Chip() string {
+	return s.chip
+}
+
+func (s SensorReading) Label() string {
+	return s.label
+}
+
+func (s SensorReading) Input() float64 {
+	return s.input
+}
+
+func (s SensorReading) Min() float64 {
+	return s.min
+}
+
+func (s SensorReading) Max() float64 {
+	return s.max
+}
+
+func (s SensorReading) Crit() float64 {
+	return s.crit
+}
+
+// Unit is the unit of the values, such as °C, V or RPM.
+func (s SensorReading) Unit() string {
+	return s.unit
+}
+
+func NewSensorReading(chip, label string, input, min, max, crit float64, unit string) SensorReading {
+	return SensorReading{
+		chip:  chip,
+		label: label,
+		unit:  unit,
+		input: input,
+		min:   min,
+		max:   max,
+		crit:  crit,
+	}
+}
+
+type Sensors interface {
+	// CpuTemperature returns the cpu temperature in degrees Celsius, 0 if unknown.
+	CpuTemperature() float64
+	// FanSpeeds returns the speed of every fan in RPM.
+	FanSpeeds() []int
+	// CpuVoltage returns the cpu core voltage in volts, 0 if unknown.
+	CpuVoltage() float64
+	Readings() []SensorReading
+}
@@ -0,0 +1,13 @@
+/*
+ * Copyright 2016-2024 The OSHI Project Contributors
+ * SPDX-License-Identifier: MIT
+ */
+
+package hardware
+
+type SoundCard interface {
+	DriverVersion() string
+	Name() string
+	// Codec returns the codecs of the card, separated by commas.
+	Codec() string
+}
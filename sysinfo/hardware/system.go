@@ -0,0 +1,80 @@
+/*
+ * Copyright 2016-2024 The OSHI Project Contributors
+ * SPDX-License-Identifier: MIT
+ */
+
+package hardware
+
+type Baseboard struct {
+	manufacturer, model, version, serialNumber string
+}
+
+func (b Baseboard) Manufacturer() string {
+	return b.manufacturer
+}
+
+func (b Baseboard) Model() string {
+	return b.model
+}
+
+func (b Baseboard) Version() string {
+	return b.version
+}
+
+func (b Baseboard) SerialNumber() string {
+	return b.serialNumber
+}
+
+func NewBaseboard(manufacturer, model, version, serialNumber string) Baseboard {
+	return Baseboard{
+		manufacturer: manufacturer,
+		model:        model,
+		version:      version,
+		serialNumber: serialNumber,
+	}
+}
+
+type Firmware struct {
+	manufacturer, name, description, version, releaseDate string
+}
+
+func (f Firmware) Manufacturer() string {
+	return f.manufacturer
+}
+
+func (f Firmware) Name() string {
+	return f.name
+}
+
+func (f Firmware) Description() string {
+	return f.description
+}
+
+func (f Firmware) Version() string {
+	return f.version
+}
+
+// ReleaseDate is the release date of the firmware in the yyyy-mm-dd format.
+func (f Firmware) ReleaseDate() string {
+	return f.releaseDate
+}
+
+func NewFirmware(manufacturer, name, description, version, releaseDate string) Firmware {
+	return Firmware{
+		manufacturer: manufacturer,
+		name:         name,
+		description:  description,
+		version:      version,
+		releaseDate:  releaseDate,
+	}
+}
+
+// ComputerSystem identifies the machine. The serial number and UUID often require elevated privileges.
+type ComputerSystem interface {
+	Manufacturer() string
+	Model() string
+	SerialNumber() string
+	HardwareUUID() string
+	Firmware() Firmware
+	Baseboard() Baseboard
+}
@@ -0,0 +1,136 @@
+/*
+ * Copyright 2016-2024 The OSHI Project Contributors
+ * SPDX-License-Identifier: MIT
+ */
+
+package hardware
+
+import (
+	"fmt"
+	"goshi/util"
+	"time"
+)
+
+// TickType indexes the arrays returned by the tick counters of CentralProcessor.
+type TickType int
+
+const (
+	TickUser TickType = iota
+	TickNice
+	TickSystem
+	TickIdle
+	TickIOWait
+	TickIRQ
+	TickSoftIRQ
+	TickSteal
+	TickTypeCount
+)
+
+// CpuTicks implements the load calculations of CentralProcessor on top of the platform tick counters.
+// The tick getters are cached for the default expiration, while the load calculations always read
+// fresh counters so that two samples taken within the expiration differ. A failed read gives zeroed
+// ticks and is not cached. Tick values are in milliseconds.
+type CpuTicks struct {
+	systemTicks          func() []int64
+	processorTicks       func() [][]int64
+	cachedSystemTicks    func() []int64
+	cachedProcessorTicks func() [][]int64
+}
+
+func (c CpuTicks) SystemCpuLoadTicks() []int64 {
+	return c.cachedSystemTicks()
+}
+
+func (c CpuTicks) ProcessorCpuLoadTicks() [][]int64 {
+	return c.cachedProcessorTicks()
+}
+
+// SystemCpuLoadBetweenTicks returns the fraction of time, between 0 and 1, the system was busy since
+// oldTicks were read. It panics if oldTicks was not returned by SystemCpuLoadTicks.
+func (c CpuTicks) SystemCpuLoadBetweenTicks(oldTicks []int64) float64 {
+	return cpuLoadBetweenTicks(oldTicks, c.systemTicks())
+}
+
+// ProcessorCpuLoadBetweenTicks returns the load of every logical processor since oldTicks were read.
+// It panics if oldTicks was not returned by ProcessorCpuLoadTicks.
+func (c CpuTicks) ProcessorCpuLoadBetweenTicks(oldTicks [][]int64) []float64 {
+	ticks := c.processorTicks()
+	if len(oldTicks) != len(ticks) {
+		panic(fmt.Sprintf("cpu: expected %d processor tick arrays, got %d", len(ticks), len(oldTicks)))
+	}
+	load := make([]float64, len(ticks))
+	for i := range ticks {
+		load[i] = cpuLoadBetweenTicks(oldTicks[i], ticks[i])
+	}
+	return load
+}
+
+// SystemCpuLoad samples the system load over delay.
+func (c CpuTicks) SystemCpuLoad(delay time.Duration) float64 {
+	start := time.Now()
+	oldTicks := c.systemTicks()
+	if toWait := delay - time.Since(start); toWait > 0 {
+		time.Sleep(toWait)
+	}
+	return c.SystemCpuLoadBetweenTicks(oldTicks)
+}
+
+// ProcessorCpuLoad samples the load of every logical processor over delay.
+func (c CpuTicks) ProcessorCpuLoad(delay time.Duration) []float64 {
+	start := time.Now()
+	oldTicks := c.processorTicks()
+	if toWait := delay - time.Since(start); toWait > 0 {
+		time.Sleep(toWait)
+	}
+	return c.ProcessorCpuLoadBetweenTicks(oldTicks)
+}
+
+func cpuLoadBetweenTicks(oldTicks, ticks []int64) float64 {
+	if len(oldTicks) != int(TickTypeCount) || len(ticks) != int(TickTypeCount) {
+		panic(fmt.Sprintf("cpu: tick arrays must have %d elements", TickTypeCount))
+	}
+	var total int64
+	for i := range ticks {
+		total += ticks[i] - oldTicks[i]
+	}
+	idle := ticks[TickIdle] + ticks[TickIOWait] - oldTicks[TickIdle] - oldTicks[TickIOWait]
+	if total <= 0 {
+		return 0
+	}
+	return float64(total-idle) / float64(total)
+}
+
+// orZeroTicks returns the ticks of supplier, or zero when it fails
+func orZeroTicks[T any](supplier func() (T, error), zero func() T) func() T {
+	return func() T {
+		ticks, err := supplier()
+		if err != nil {
+			return zero()
+		}
+		return ticks
+	}
+}
+
+func NewCpuTicks(
+	systemTicks func() ([]int64, error),
+	processorTicks func() ([][]int64, error),
+	logicalProcessorCount int,
+) CpuTicks {
+	ttl := util.DefaultExpiration()
+	zeroSystem := func() []int64 {
+		return make([]int64, TickTypeCount)
+	}
+	zeroProcessors := func() [][]int64 {
+		ticks := make([][]int64, logicalProcessorCount)
+		for i := range ticks {
+			ticks[i] = zeroSystem()
+		}
+		return ticks
+	}
+	return CpuTicks{
+		systemTicks:          orZeroTicks(systemTicks, zeroSystem),
+		processorTicks:       orZeroTicks(processorTicks, zeroProcessors),
+		cachedSystemTicks:    orZeroTicks(util.MemoizeWithError(systemTicks, ttl), zeroSystem),
+		cachedProcessorTicks: orZeroTicks(util.MemoizeWithError(processorTicks, ttl), zeroProcessors),
+	}
+}
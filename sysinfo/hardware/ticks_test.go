@@ -0,0 +1,50 @@
+/*
+ * Copyright 2016-2024 The OSHI Project Contributors
+ * SPDX-License-Identifier: MIT
+ */
+
+package hardware
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestCpuTicksFailedRead(t *testing.T) {
+	fail := true
+	reads := 0
+	processorTicks := func() ([][]int64, error) {
+		reads++
+		if fail {
+			return nil, errors.New("no counters")
+		}
+		return [][]int64{{1, 0, 0, 1, 0, 0, 0, 0}, {2, 0, 0, 2, 0, 0, 0, 0}}, nil
+	}
+	systemTicks := func() ([]int64, error) {
+		return nil, errors.New("no counters")
+	}
+	c := NewCpuTicks(systemTicks, processorTicks, 2)
+
+	if got := c.SystemCpuLoadTicks(); !reflect.DeepEqual(got, make([]int64, TickTypeCount)) {
+		t.Errorf("system ticks = %v, want zeroes", got)
+	}
+	// zeroed arrays for every logical processor, so the load calculation does not panic
+	old := c.ProcessorCpuLoadTicks()
+	if len(old) != 2 || len(old[1]) != int(TickTypeCount) {
+		t.Fatalf("processor ticks = %v, want 2 zeroed arrays", old)
+	}
+	if load := c.ProcessorCpuLoadBetweenTicks(old); !reflect.DeepEqual(load, []float64{0, 0}) {
+		t.Errorf("load = %v", load)
+	}
+
+	// the failure is not cached
+	fail = false
+	before := reads
+	if got := c.ProcessorCpuLoadTicks(); got[1][0] != 2 {
+		t.Errorf("processor ticks = %v after a successful read", got)
+	}
+	if reads != before+1 {
+		t.Errorf("%d reads, want the failed read to be retried", reads-before)
+	}
+}
@@ -0,0 +1,57 @@
+/*
+ * Copyright 2016-2024 The OSHI Project Contributors
+ * SPDX-License-Identifier: MIT
+ */
+
+package hardware
+
+type UsbDevice struct {
+	name, vendor, vendorId, productId, serialNumber, uniqueDeviceId string
+	connectedDevices                                                []UsbDevice
+}
+
+func (u UsbDevice) Name() string {
+	return u.name
+}
+
+func (u UsbDevice) Vendor() string {
+	return u.vendor
+}
+
+// VendorId is the 4 digit hexadecimal vendor id, such as 046d.
+func (u UsbDevice) VendorId() string {
+	return u.vendorId
+}
+
+func (u UsbDevice) ProductId() string {
+	return u.productId
+}
+
+func (u UsbDevice) SerialNumber() string {
+	return u.serialNumber
+}
+
+// UniqueDeviceId identifies the device among those connected, it is the serial number if the device has one.
+func (u UsbDevice) UniqueDeviceId() string {
+	return u.uniqueDeviceId
+}
+
+// ConnectedDevices returns the devices attached to a hub or controller, always empty in a flat list.
+func (u UsbDevice) ConnectedDevices() []UsbDevice {
+	return u.connectedDevices
+}
+
+func NewUsbDevice(
+	name, vendor, vendorId, productId, serialNumber, uniqueDeviceId string,
+	connectedDevices []UsbDevice,
+) UsbDevice {
+	return UsbDevice{
+		name:             name,
+		vendor:           vendor,
+		vendorId:         vendorId,
+		productId:        productId,
+		serialNumber:     serialNumber,
+		uniqueDeviceId:   uniqueDeviceId,
+		connectedDevices: connectedDevices,
+	}
+}
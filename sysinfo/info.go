@@ -10,22 +10,57 @@ import (
 	"goshi/linux"
 	"goshi/macos"
 	"goshi/sysinfo/hardware"
+	"goshi/sysinfo/software"
 	hardware2 "goshi/windows/hardware"
+	software2 "goshi/windows/software"
 	"runtime"
+	"sync"
 )
 
-func Processor() (hardware.CentralProcessor, error) {
-	var proc hardware.CentralProcessor
+var (
+	// systemInfoOnce builds the SystemInfo shared by the package functions, so that the hardware
+	// queries it memoizes are reused between calls
+	systemInfoOnce sync.Once
+	systemInfo     SystemInfo
+	systemInfoErr  error
+)
+
+// SystemInfo is the entry point to the hardware and operating system of the current platform.
+type SystemInfo struct {
+	hardware hardware.HardwareAbstractionLayer
+	os       software.OperatingSystem
+}
+
+func (s SystemInfo) Hardware() hardware.HardwareAbstractionLayer {
+	return s.hardware
+}
+
+func (s SystemInfo) OperatingSystem() software.OperatingSystem {
+	return s.os
+}
+
+func NewSystemInfo() (SystemInfo, error) {
+	var info SystemInfo
 	var err error
 	switch runtime.GOOS {
 	case "windows":
-		proc, err = hardware2.Processor()
+		info = SystemInfo{hardware2.HardwareAbstractionLayer(), software2.OperatingSystem()}
 	case "linux":
-		proc, err = linux.Processor()
+		info = SystemInfo{linux.HardwareAbstractionLayer(), linux.OperatingSystem()}
 	case "darwin":
-		proc, err = macos.Processor()
+		info = SystemInfo{macos.HardwareAbstractionLayer(), macos.OperatingSystem()}
 	default:
 		err = errors.New("unsupported os")
 	}
-	return proc, err
+	return info, err
+}
+
+func Processor() (hardware.CentralProcessor, error) {
+	systemInfoOnce.Do(func() {
+		systemInfo, systemInfoErr = NewSystemInfo()
+	})
+	if systemInfoErr != nil {
+		return nil, systemInfoErr
+	}
+	return systemInfo.Hardware().Processor()
 }
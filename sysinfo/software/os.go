@@ -0,0 +1,54 @@
+/*
+ * Copyright 2016-2024 The OSHI Project Contributors
+ * SPDX-License-Identifier: MIT
+ */
+
+package software
+
+import (
+	"fmt"
+	"strings"
+)
+
+type OSVersionInfo struct {
+	version, codeName, buildNumber string
+}
+
+func (o OSVersionInfo) Version() string {
+	return o.version
+}
+
+func (o OSVersionInfo) CodeName() string {
+	return o.codeName
+}
+
+func (o OSVersionInfo) BuildNumber() string {
+	return o.buildNumber
+}
+
+func (o OSVersionInfo) String() string {
+	sb := strings.Builder{}
+	sb.WriteString(o.version)
+	if len(o.codeName) != 0 {
+		sb.WriteString(fmt.Sprintf(" (%s)", o.codeName))
+	}
+	if len(o.buildNumber) != 0 {
+		sb.WriteString(fmt.Sprintf(" build %s", o.buildNumber))
+	}
+	return sb.String()
+}
+
+func NewOSVersionInfo(version, codeName, buildNumber string) OSVersionInfo {
+	return OSVersionInfo{
+		version:     version,
+		codeName:    codeName,
+		buildNumber: buildNumber,
+	}
+}
+
+type OperatingSystem interface {
+	Family() string
+	Manufacturer() string
+	VersionInfo() OSVersionInfo
+	Bitness() int
+}
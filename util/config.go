@@ -0,0 +1,55 @@
+/*
+ * Copyright 2016-2024 The OSHI Project Contributors
+ * SPDX-License-Identifier: MIT
+ */
+
+package util
+
+import (
+	"os"
+	"strings"
+	"sync"
+)
+
+const (
+	// ConfigRootPath is the directory the linux pseudo-filesystems (/proc, /sys, /etc, /run) are read from.
+	ConfigRootPath = "goshi.util.root.path"
+	// ConfigMemoizerExpiration is the default time to live of memoized values, in milliseconds.
+	ConfigMemoizerExpiration = "goshi.util.memoizer.expiration"
+)
+
+var (
+	configMu sync.RWMutex
+	config   = make(map[string]string)
+)
+
+// SetConfig overrides the value of a configuration key. An empty value restores the default.
+func SetConfig(key, value string) {
+	configMu.Lock()
+	defer configMu.Unlock()
+	if len(value) == 0 {
+		delete(config, key)
+		return
+	}
+	config[key] = value
+}
+
+// ConfigString returns the value set for key, then the environment variable named after it
+// (goshi.util.root.path is read from GOSHI_UTIL_ROOT_PATH), then defaultValue.
+func ConfigString(key, defaultValue string) string {
+	configMu.RLock()
+	val, exists := config[key]
+	configMu.RUnlock()
+	if exists {
+		return val
+	}
+	env := strings.ToUpper(strings.ReplaceAll(key, ".", "_"))
+	if val, exists = os.LookupEnv(env); exists && len(val) != 0 {
+		return val
+	}
+	return defaultValue
+}
+
+func ConfigInt64(key string, defaultValue int64) int64 {
+	return ParseInt64OrDefault(ConfigString(key, ""), defaultValue)
+}
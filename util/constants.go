@@ -6,7 +6,8 @@
 package util
 
 const (
-	Unknown  = "unknown"
-	AT_HWCAP = 16
-	Bits     = 32 << (^uint(0) >> 63)
+	Unknown   = "unknown"
+	AT_HWCAP  = 16
+	AT_HWCAP2 = 26
+	Bits      = 32 << (^uint(0) >> 63)
 )
@@ -0,0 +1,107 @@
+/*
+ * Copyright 2016-2024 The OSHI Project Contributors
+ * SPDX-License-Identifier: MIT
+ */
+
+package edid
+
+const (
+	ceaAudioBlock   = 1
+	ceaVideoBlock   = 2
+	ceaVendorBlock  = 3
+	ceaSpeakerBlock = 4
+)
+
+var (
+	// sample rates of the bits of the second byte of a short audio descriptor
+	audioSampleRates = []int{32_000, 44_100, 48_000, 88_200, 96_000, 176_400, 192_000}
+)
+
+func parseAudioBlock(data []byte) []ShortAudioDescriptor {
+	formats := make([]ShortAudioDescriptor, 0, len(data)/3)
+	for i := 0; i+3 <= len(data); i += 3 {
+		format := ShortAudioDescriptor{
+			Format:      int(data[i]>>3) & 0x0F,
+			Channels:    int(data[i]&0x07) + 1,
+			SampleRates: make([]int, 0),
+		}
+		for bit, rate := range audioSampleRates {
+			if data[i+1]&(1<<bit) != 0 {
+				format.SampleRates = append(format.SampleRates, rate)
+			}
+		}
+		formats = append(formats, format)
+	}
+	return formats
+}
+
+func parseVideoBlock(data []byte) []ShortVideoDescriptor {
+	modes := make([]ShortVideoDescriptor, 0, len(data))
+	for _, svd := range data {
+		// bit 7 flags a native mode only for the codes 1 to 64, higher codes use all 8 bits
+		if svd&0x7F >= 1 && svd&0x7F <= 64 && svd&0x80 != 0 {
+			modes = append(modes, ShortVideoDescriptor{VIC: int(svd & 0x7F), Native: true})
+		} else {
+			modes = append(modes, ShortVideoDescriptor{VIC: int(svd)})
+		}
+	}
+	return modes
+}
+
+// parseCEAExtension decodes the data block collection and the detailed timings of a CEA-861 block
+func parseCEAExtension(block []byte) CEAExtension {
+	c := CEAExtension{
+		Revision:        int(block[1]),
+		VideoModes:      make([]ShortVideoDescriptor, 0),
+		AudioFormats:    make([]ShortAudioDescriptor, 0),
+		VendorBlocks:    make([]VendorBlock, 0),
+		DetailedTimings: make([]DetailedTiming, 0),
+	}
+	dtdOffset := int(block[2])
+	if dtdOffset < 4 || dtdOffset > BlockSize-1 {
+		// 0 means no data blocks and no detailed timings, 1 to 3 would point into the header
+		return c
+	}
+	if c.Revision >= 2 {
+		c.Underscan = block[3]&0x80 != 0
+		c.BasicAudio = block[3]&0x40 != 0
+		c.YCbCr444 = block[3]&0x20 != 0
+		c.YCbCr422 = block[3]&0x10 != 0
+		c.NativeFormats = int(block[3] & 0x0F)
+	}
+	// revision 1 blocks have no data block collection
+	for off := 4; c.Revision >= 3 && off < dtdOffset; {
+		tag, length := block[off]>>5, int(block[off]&0x1F)
+		if off+1+length > dtdOffset {
+			break
+		}
+		data := block[off+1 : off+1+length]
+		switch tag {
+		case ceaAudioBlock:
+			c.AudioFormats = append(c.AudioFormats, parseAudioBlock(data)...)
+		case ceaVideoBlock:
+			c.VideoModes = append(c.VideoModes, parseVideoBlock(data)...)
+		case ceaVendorBlock:
+			if len(data) >= 3 {
+				c.VendorBlocks = append(c.VendorBlocks, VendorBlock{
+					OUI:     uint32(data[0]) | uint32(data[1])<<8 | uint32(data[2])<<16,
+					Payload: data[3:],
+				})
+			}
+		case ceaSpeakerBlock:
+			if len(data) >= 3 {
+				c.SpeakerAllocation = uint32(data[0]) | uint32(data[1])<<8 | uint32(data[2])<<16
+			}
+		}
+		off += 1 + length
+	}
+	// the last byte is the checksum
+	for off := dtdOffset; off+descriptorSize <= BlockSize-1; off += descriptorSize {
+		d := block[off : off+descriptorSize]
+		if d[0] == 0 && d[1] == 0 {
+			break
+		}
+		c.DetailedTimings = append(c.DetailedTimings, parseDetailedTiming(d))
+	}
+	return c
+}
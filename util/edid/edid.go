@@ -0,0 +1,270 @@
+/*
+ * Copyright 2016-2024 The OSHI Project Contributors
+ * SPDX-License-Identifier: MIT
+ */
+
+// Package edid decodes the Extended Display Identification Data monitors report to their host,
+// including the CEA-861 extension blocks of HDMI and DisplayPort sinks.
+package edid
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	BlockSize = 128
+
+	descriptorOffset = 54
+	descriptorSize   = 18
+	descriptorCount  = 4
+
+	descriptorSerial      = 0xFF
+	descriptorText        = 0xFE
+	descriptorRangeLimits = 0xFD
+	descriptorName        = 0xFC
+
+	extensionCEA = 0x02
+)
+
+var (
+	header = []byte{0x00, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0x00}
+
+	ErrInvalidHeader = errors.New("edid: invalid header")
+)
+
+// DetailedTiming is a video mode described by its pixel clock and blanking intervals.
+type DetailedTiming struct {
+	// PixelClock is in Hz.
+	PixelClock                               int64
+	HActive, HBlank, HSyncOffset, HSyncWidth int
+	VActive, VBlank, VSyncOffset, VSyncWidth int
+	// WidthMm and HeightMm are the size of the image on the screen.
+	WidthMm, HeightMm int
+	Interlaced        bool
+}
+
+// RefreshRate returns the vertical refresh rate in Hz.
+func (d DetailedTiming) RefreshRate() float64 {
+	total := (d.HActive + d.HBlank) * (d.VActive + d.VBlank)
+	if total == 0 {
+		return 0
+	}
+	return float64(d.PixelClock) / float64(total)
+}
+
+func (d DetailedTiming) String() string {
+	return fmt.Sprintf("%dx%d@%.2fHz", d.HActive, d.VActive, d.RefreshRate())
+}
+
+// RangeLimits are the frequencies the monitor accepts.
+type RangeLimits struct {
+	// MinVRate and MaxVRate are in Hz, MinHRate and MaxHRate in kHz.
+	MinVRate, MaxVRate, MinHRate, MaxHRate int
+	// MaxPixelClock is in MHz, 0 if not given.
+	MaxPixelClock int
+}
+
+type ShortVideoDescriptor struct {
+	// VIC is the CEA-861 video identification code of the mode.
+	VIC    int
+	Native bool
+}
+
+type ShortAudioDescriptor struct {
+	// Format is the audio format code, 1 for LPCM.
+	Format   int
+	Channels int
+	// SampleRates are in Hz.
+	SampleRates []int
+}
+
+type VendorBlock struct {
+	// OUI is the IEEE identifier of the vendor, 0x000C03 for HDMI 1.x and 0xC45DD8 for HDMI Forum.
+	OUI     uint32
+	Payload []byte
+}
+
+// CEAExtension is a CEA-861 extension block.
+type CEAExtension struct {
+	Revision                                  int
+	Underscan, BasicAudio, YCbCr444, YCbCr422 bool
+	NativeFormats                             int
+	VideoModes                                []ShortVideoDescriptor
+	AudioFormats                              []ShortAudioDescriptor
+	VendorBlocks                              []VendorBlock
+	// SpeakerAllocation is the bit field of the speaker allocation data block.
+	SpeakerAllocation uint32
+	DetailedTimings   []DetailedTiming
+}
+
+// HDMI reports whether the sink declares HDMI support with an HDMI vendor block.
+func (c CEAExtension) HDMI() bool {
+	for _, block := range c.VendorBlocks {
+		if block.OUI == 0x000C03 {
+			return true
+		}
+	}
+	return false
+}
+
+type EDID struct {
+	// ManufacturerID is the 3 letter PNP id of the manufacturer, such as DEL.
+	ManufacturerID string
+	ProductCode    uint16
+	SerialNumber   uint32
+	// Week is 0 if the manufacturer did not give it. If ModelYear is set, Year is the model year
+	// rather than the year of manufacture.
+	Week, Year int
+	ModelYear  bool
+	// Version is the EDID version and revision, such as 1.4.
+	Version string
+	Digital bool
+	// WidthCm and HeightCm are the maximum image size, 0 for projectors.
+	WidthCm, HeightCm int
+	// PreferredTiming is the first detailed timing, nil if the base block has none.
+	PreferredTiming *DetailedTiming
+	DetailedTimings []DetailedTiming
+	MonitorName     string
+	// SerialString is the serial number descriptor, which most monitors use instead of SerialNumber.
+	SerialString string
+	Text         string
+	RangeLimits  *RangeLimits
+	Extensions   []CEAExtension
+}
+
+func (e EDID) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s %s (%04X), EDID %s", e.ManufacturerID, e.MonitorName, e.ProductCode, e.Version)
+	if e.WidthCm > 0 && e.HeightCm > 0 {
+		fmt.Fprintf(&b, ", %dx%d cm", e.WidthCm, e.HeightCm)
+	}
+	if e.PreferredTiming != nil {
+		fmt.Fprintf(&b, ", %s", e.PreferredTiming)
+	}
+	return b.String()
+}
+
+// manufacturerID decodes the three 5 bit letters, 'A' being 1, of the big endian manufacturer word
+func manufacturerID(b []byte) string {
+	id := binary.BigEndian.Uint16(b)
+	return string([]byte{
+		byte(id>>10&0x1F) + 'A' - 1,
+		byte(id>>5&0x1F) + 'A' - 1,
+		byte(id&0x1F) + 'A' - 1,
+	})
+}
+
+func parseDetailedTiming(d []byte) DetailedTiming {
+	return DetailedTiming{
+		PixelClock:  int64(binary.LittleEndian.Uint16(d)) * 10_000,
+		HActive:     int(d[2]) | int(d[4]&0xF0)<<4,
+		HBlank:      int(d[3]) | int(d[4]&0x0F)<<8,
+		VActive:     int(d[5]) | int(d[7]&0xF0)<<4,
+		VBlank:      int(d[6]) | int(d[7]&0x0F)<<8,
+		HSyncOffset: int(d[8]) | int(d[11]&0xC0)<<2,
+		HSyncWidth:  int(d[9]) | int(d[11]&0x30)<<4,
+		VSyncOffset: int(d[10]>>4) | int(d[11]&0x0C)<<2,
+		VSyncWidth:  int(d[10]&0x0F) | int(d[11]&0x03)<<4,
+		WidthMm:     int(d[12]) | int(d[14]&0xF0)<<4,
+		HeightMm:    int(d[13]) | int(d[14]&0x0F)<<8,
+		Interlaced:  d[17]&0x80 != 0,
+	}
+}
+
+// descriptorString decodes the text of a display descriptor, terminated by a line feed and padded with spaces
+func descriptorString(d []byte) string {
+	text := d[5:descriptorSize]
+	if i := bytes.IndexByte(text, '\n'); i >= 0 {
+		text = text[:i]
+	}
+	return strings.TrimSpace(string(text))
+}
+
+func parseRangeLimits(d []byte) *RangeLimits {
+	limits := &RangeLimits{
+		MinVRate:      int(d[5]),
+		MaxVRate:      int(d[6]),
+		MinHRate:      int(d[7]),
+		MaxHRate:      int(d[8]),
+		MaxPixelClock: int(d[9]) * 10,
+	}
+	// EDID 1.4 adds 255 to the rates flagged in byte 4
+	if d[4]&0x01 != 0 {
+		limits.MinVRate += 255
+	}
+	if d[4]&0x02 != 0 {
+		limits.MaxVRate += 255
+	}
+	if d[4]&0x04 != 0 {
+		limits.MinHRate += 255
+	}
+	if d[4]&0x08 != 0 {
+		limits.MaxHRate += 255
+	}
+	return limits
+}
+
+// Decode decodes the base block of an EDID and its CEA extensions. Extensions that are truncated or
+// of other types are skipped.
+func Decode(b []byte) (EDID, error) {
+	var e EDID
+	if len(b) < BlockSize {
+		return e, fmt.Errorf("edid: expected at least %d bytes, got %d", BlockSize, len(b))
+	}
+	if !bytes.Equal(b[:len(header)], header) {
+		return e, ErrInvalidHeader
+	}
+	var sum byte
+	for _, v := range b[:BlockSize] {
+		sum += v
+	}
+	if sum != 0 {
+		return e, fmt.Errorf("edid: invalid checksum of the base block")
+	}
+	e.ManufacturerID = manufacturerID(b[8:10])
+	e.ProductCode = binary.LittleEndian.Uint16(b[10:])
+	e.SerialNumber = binary.LittleEndian.Uint32(b[12:])
+	e.Week = int(b[16])
+	e.Year = int(b[17]) + 1990
+	if e.Week == 0xFF {
+		e.Week = 0
+		e.ModelYear = true
+	}
+	e.Version = fmt.Sprintf("%d.%d", b[18], b[19])
+	e.Digital = b[20]&0x80 != 0
+	e.WidthCm = int(b[21])
+	e.HeightCm = int(b[22])
+
+	for i := 0; i < descriptorCount; i++ {
+		d := b[descriptorOffset+i*descriptorSize : descriptorOffset+(i+1)*descriptorSize]
+		if d[0] != 0 || d[1] != 0 {
+			e.DetailedTimings = append(e.DetailedTimings, parseDetailedTiming(d))
+			continue
+		}
+		switch d[3] {
+		case descriptorName:
+			e.MonitorName = descriptorString(d)
+		case descriptorSerial:
+			e.SerialString = descriptorString(d)
+		case descriptorText:
+			e.Text = descriptorString(d)
+		case descriptorRangeLimits:
+			e.RangeLimits = parseRangeLimits(d)
+		}
+	}
+	if len(e.DetailedTimings) > 0 {
+		e.PreferredTiming = &e.DetailedTimings[0]
+	}
+
+	for i := 1; i <= int(b[126]) && (i+1)*BlockSize <= len(b); i++ {
+		block := b[i*BlockSize : (i+1)*BlockSize]
+		if block[0] == extensionCEA {
+			e.Extensions = append(e.Extensions, parseCEAExtension(block))
+		}
+	}
+	return e, nil
+}
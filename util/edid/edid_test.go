@@ -0,0 +1,180 @@
+/*
+ * Copyright 2016-2024 The OSHI Project Contributors
+ * SPDX-License-Identifier: MIT
+ */
+
+package edid
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func readBlob(t *testing.T, name string) []byte {
+	t.Helper()
+	b, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func TestDecodeAnalog(t *testing.T) {
+	e, err := Decode(readBlob(t, "analog-1.3.bin"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e.ManufacturerID != "SAM" || e.ProductCode != 0x0150 || e.SerialNumber != 0x01020304 {
+		t.Errorf("identification = %s %04X %08X", e.ManufacturerID, e.ProductCode, e.SerialNumber)
+	}
+	if e.Week != 20 || e.Year != 2008 || e.ModelYear {
+		t.Errorf("manufactured week %d of %d, model year %t", e.Week, e.Year, e.ModelYear)
+	}
+	if e.Version != "1.3" || e.Digital {
+		t.Errorf("version %s, digital %t", e.Version, e.Digital)
+	}
+	if e.WidthCm != 34 || e.HeightCm != 27 {
+		t.Errorf("size = %dx%d cm", e.WidthCm, e.HeightCm)
+	}
+	if e.MonitorName != "SyncMaster" || e.SerialString != "H9XS900123" {
+		t.Errorf("name %q, serial %q", e.MonitorName, e.SerialString)
+	}
+	want := DetailedTiming{
+		PixelClock: 108_000_000,
+		HActive:    1280, HBlank: 408, HSyncOffset: 48, HSyncWidth: 112,
+		VActive: 1024, VBlank: 42, VSyncOffset: 1, VSyncWidth: 3,
+		WidthMm: 338, HeightMm: 270,
+	}
+	if e.PreferredTiming == nil || *e.PreferredTiming != want {
+		t.Fatalf("preferred timing = %+v, want %+v", e.PreferredTiming, want)
+	}
+	if got := e.PreferredTiming.String(); got != "1280x1024@60.02Hz" {
+		t.Errorf("preferred timing = %s", got)
+	}
+	if len(e.DetailedTimings) != 1 {
+		t.Errorf("got %d detailed timings, want 1", len(e.DetailedTimings))
+	}
+	wantLimits := RangeLimits{MinVRate: 56, MaxVRate: 75, MinHRate: 30, MaxHRate: 81, MaxPixelClock: 140}
+	if e.RangeLimits == nil || *e.RangeLimits != wantLimits {
+		t.Errorf("range limits = %+v, want %+v", e.RangeLimits, wantLimits)
+	}
+	if len(e.Extensions) != 0 {
+		t.Errorf("got %d extensions, want none", len(e.Extensions))
+	}
+}
+
+func TestDecodeDigitalCEA(t *testing.T) {
+	e, err := Decode(readBlob(t, "digital-1.4-cea.bin"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e.ManufacturerID != "DEL" || e.ProductCode != 0xA0C5 {
+		t.Errorf("identification = %s %04X", e.ManufacturerID, e.ProductCode)
+	}
+	if e.Week != 0 || e.Year != 2020 || !e.ModelYear {
+		t.Errorf("week %d of %d, model year %t", e.Week, e.Year, e.ModelYear)
+	}
+	if e.Version != "1.4" || !e.Digital {
+		t.Errorf("version %s, digital %t", e.Version, e.Digital)
+	}
+	if e.MonitorName != "DELL U2720Q" || e.SerialString != "ABC1234" {
+		t.Errorf("name %q, serial %q", e.MonitorName, e.SerialString)
+	}
+	if got := e.String(); got != "DEL DELL U2720Q (A0C5), EDID 1.4, 60x34 cm, 1920x1080@60.00Hz" {
+		t.Errorf("String() = %s", got)
+	}
+	// the maximum vertical rate is flagged with a 255 Hz offset
+	wantLimits := RangeLimits{MinVRate: 48, MaxVRate: 360, MinHRate: 30, MaxHRate: 160, MaxPixelClock: 600}
+	if e.RangeLimits == nil || *e.RangeLimits != wantLimits {
+		t.Errorf("range limits = %+v, want %+v", e.RangeLimits, wantLimits)
+	}
+
+	if len(e.Extensions) != 1 {
+		t.Fatalf("got %d extensions, want 1", len(e.Extensions))
+	}
+	c := e.Extensions[0]
+	if c.Revision != 3 || !c.Underscan || !c.BasicAudio || !c.YCbCr444 || !c.YCbCr422 || c.NativeFormats != 1 {
+		t.Errorf("cea header = %+v", c)
+	}
+	wantModes := []ShortVideoDescriptor{{VIC: 16, Native: true}, {VIC: 4}, {VIC: 31}}
+	if !reflect.DeepEqual(c.VideoModes, wantModes) {
+		t.Errorf("video modes = %+v, want %+v", c.VideoModes, wantModes)
+	}
+	wantAudio := []ShortAudioDescriptor{{Format: 1, Channels: 2, SampleRates: []int{32_000, 44_100, 48_000}}}
+	if !reflect.DeepEqual(c.AudioFormats, wantAudio) {
+		t.Errorf("audio formats = %+v, want %+v", c.AudioFormats, wantAudio)
+	}
+	if !c.HDMI() || len(c.VendorBlocks) != 1 || !reflect.DeepEqual(c.VendorBlocks[0].Payload, []byte{0x10, 0x00}) {
+		t.Errorf("vendor blocks = %+v", c.VendorBlocks)
+	}
+	if c.SpeakerAllocation != 0x01 {
+		t.Errorf("speaker allocation = %#x", c.SpeakerAllocation)
+	}
+	if len(c.DetailedTimings) != 1 || c.DetailedTimings[0].String() != "1280x720@60.00Hz" {
+		t.Errorf("cea detailed timings = %v", c.DetailedTimings)
+	}
+}
+
+func TestDecodeInvalid(t *testing.T) {
+	badHeader := readBlob(t, "analog-1.3.bin")
+	badHeader[0] = 0xFF
+	tests := []struct {
+		name string
+		blob []byte
+		err  error
+	}{
+		{"truncated", readBlob(t, "truncated.bin"), nil},
+		{"bad checksum", readBlob(t, "bad-checksum.bin"), nil},
+		{"bad header", badHeader, ErrInvalidHeader},
+		{"empty", nil, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Decode(tt.blob)
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			if tt.err != nil && !errors.Is(err, tt.err) {
+				t.Errorf("error = %v, want %v", err, tt.err)
+			}
+		})
+	}
+}
+
+func TestCEADetailedTimingOffset(t *testing.T) {
+	blob := readBlob(t, "digital-1.4-cea.bin")
+	block := blob[BlockSize:]
+	tests := []struct {
+		offset      byte
+		wantTimings int
+	}{
+		{0, 0},
+		{1, 0},
+		{2, 0},
+		{3, 0},
+		{BlockSize, 0},
+		{block[2], 1},
+	}
+	for _, tt := range tests {
+		b := append([]byte(nil), block...)
+		b[2] = tt.offset
+		c := parseCEAExtension(b)
+		if len(c.DetailedTimings) != tt.wantTimings {
+			t.Errorf("offset %d: got %d detailed timings, want %d", tt.offset, len(c.DetailedTimings), tt.wantTimings)
+		}
+	}
+}
+
+func TestDecodeTruncatedExtension(t *testing.T) {
+	blob := readBlob(t, "digital-1.4-cea.bin")
+	e, err := Decode(blob[:BlockSize+50])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(e.Extensions) != 0 {
+		t.Errorf("got %d extensions from a truncated block, want none", len(e.Extensions))
+	}
+}
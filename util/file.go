@@ -0,0 +1,58 @@
+/*
+ * Copyright 2016-2024 The OSHI Project Contributors
+ * SPDX-License-Identifier: MIT
+ */
+
+package util
+
+import (
+	"os"
+	"strconv"
+	"strings"
+)
+
+// ReadLines returns the lines of the file, or nil if it cannot be read.
+func ReadLines(path string) []string {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	s := strings.TrimRight(string(b), "\n")
+	if len(s) == 0 {
+		return nil
+	}
+	return strings.Split(s, "\n")
+}
+
+// ReadString returns the trimmed contents of the file, or an empty string if it cannot be read.
+func ReadString(path string) string {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(b))
+}
+
+func ReadInt64OrDefault(path string, defaultValue int64) int64 {
+	return ParseInt64OrDefault(ReadString(path), defaultValue)
+}
+
+func ReadIntOrDefault(path string, defaultValue int) int {
+	if val, err := strconv.Atoi(ReadString(path)); err == nil {
+		return val
+	}
+	return defaultValue
+}
+
+// ReadKeyValues splits every line of the file on the first sep and returns the trimmed pairs.
+func ReadKeyValues(path, sep string) map[string]string {
+	res := make(map[string]string)
+	for _, line := range ReadLines(path) {
+		key, value, found := strings.Cut(line, sep)
+		if !found {
+			continue
+		}
+		res[strings.TrimSpace(key)] = strings.TrimSpace(value)
+	}
+	return res
+}
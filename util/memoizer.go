@@ -0,0 +1,68 @@
+/*
+ * Copyright 2016-2024 The OSHI Project Contributors
+ * SPDX-License-Identifier: MIT
+ */
+
+package util
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	defaultExpiration = 300 * time.Millisecond
+)
+
+// DefaultExpiration returns the time to live set through ConfigMemoizerExpiration, 300ms if unset.
+func DefaultExpiration() time.Duration {
+	ms := ConfigInt64(ConfigMemoizerExpiration, -1)
+	if ms < 0 {
+		return defaultExpiration
+	}
+	return time.Duration(ms) * time.Millisecond
+}
+
+// Memoize returns a function that calls supplier at most once every ttl and returns the cached
+// value in between. A negative ttl never expires the value. It is safe for concurrent use.
+func Memoize[T any](supplier func() T, ttl time.Duration) func() T {
+	var mu sync.Mutex
+	var value T
+	var expiration time.Time
+	valid := false
+	return func() T {
+		mu.Lock()
+		defer mu.Unlock()
+		now := time.Now()
+		if !valid || (ttl >= 0 && !now.Before(expiration)) {
+			value = supplier()
+			expiration = now.Add(ttl)
+			valid = true
+		}
+		return value
+	}
+}
+
+// MemoizeWithError is like Memoize, except that failed calls are not cached and are retried on the next call.
+func MemoizeWithError[T any](supplier func() (T, error), ttl time.Duration) func() (T, error) {
+	var mu sync.Mutex
+	var value T
+	var expiration time.Time
+	valid := false
+	return func() (T, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		now := time.Now()
+		if !valid || (ttl >= 0 && !now.Before(expiration)) {
+			val, err := supplier()
+			if err != nil {
+				valid = false
+				return val, err
+			}
+			value = val
+			expiration = now.Add(ttl)
+			valid = true
+		}
+		return value, nil
+	}
+}
@@ -0,0 +1,134 @@
+/*
+ * Copyright 2016-2024 The OSHI Project Contributors
+ * SPDX-License-Identifier: MIT
+ */
+
+package util
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// counter returns a supplier of increasing values and the number of times it was called
+func counter() (func() int64, *atomic.Int64) {
+	var calls atomic.Int64
+	return func() int64 { return calls.Add(1) }, &calls
+}
+
+func TestMemoizeExpiration(t *testing.T) {
+	tests := []struct {
+		name      string
+		ttl       time.Duration
+		sleep     time.Duration
+		wantCalls int64
+	}{
+		{"cached within ttl", time.Hour, 0, 1},
+		{"refreshed after ttl", time.Millisecond, 5 * time.Millisecond, 3},
+		{"zero ttl never caches", 0, 0, 3},
+		{"negative ttl never expires", -1, 5 * time.Millisecond, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			supplier, calls := counter()
+			memoized := Memoize(supplier, tt.ttl)
+			var last int64
+			for i := 0; i < 3; i++ {
+				last = memoized()
+				time.Sleep(tt.sleep)
+			}
+			if calls.Load() != tt.wantCalls || last != tt.wantCalls {
+				t.Errorf("supplier called %d times, last value %d, want %d", calls.Load(), last, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestMemoizeWithErrorRetries(t *testing.T) {
+	errFailed := errors.New("failed")
+	var calls int
+	memoized := MemoizeWithError(func() (int, error) {
+		calls++
+		// the first two calls fail
+		if calls <= 2 {
+			return -1, errFailed
+		}
+		return calls, nil
+	}, -1)
+	for i := 0; i < 2; i++ {
+		if _, err := memoized(); !errors.Is(err, errFailed) {
+			t.Fatalf("call %d: error = %v, want %v", i, err, errFailed)
+		}
+	}
+	// failures are not cached, the first success is
+	for i := 0; i < 3; i++ {
+		if val, err := memoized(); err != nil || val != 3 {
+			t.Errorf("value = %d, %v, want 3", val, err)
+		}
+	}
+	if calls != 3 {
+		t.Errorf("supplier called %d times, want 3", calls)
+	}
+}
+
+func TestMemoizeWithErrorExpiration(t *testing.T) {
+	var calls int
+	memoized := MemoizeWithError(func() (int, error) {
+		calls++
+		return calls, nil
+	}, time.Millisecond)
+	first, _ := memoized()
+	time.Sleep(5 * time.Millisecond)
+	if second, _ := memoized(); first != 1 || second != 2 {
+		t.Errorf("values = %d, %d, want 1, 2", first, second)
+	}
+}
+
+func TestMemoizeConcurrent(t *testing.T) {
+	var calls atomic.Int64
+	memoized := Memoize(func() int64 {
+		// hold the lock long enough for the other callers to wait on it
+		time.Sleep(10 * time.Millisecond)
+		return calls.Add(1)
+	}, -1)
+	memoizedWithError := MemoizeWithError(func() (int64, error) {
+		return memoized(), nil
+	}, -1)
+	var wg sync.WaitGroup
+	values := make([]int64, 50)
+	for i := range values {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if i%2 == 0 {
+				values[i] = memoized()
+			} else {
+				values[i], _ = memoizedWithError()
+			}
+		}()
+	}
+	wg.Wait()
+	if calls.Load() != 1 {
+		t.Errorf("supplier called %d times, want 1", calls.Load())
+	}
+	for i, v := range values {
+		if v != 1 {
+			t.Errorf("caller %d got %d, want 1", i, v)
+		}
+	}
+}
+
+func TestDefaultExpiration(t *testing.T) {
+	t.Setenv("GOSHI_UTIL_MEMOIZER_EXPIRATION", "")
+	if got := DefaultExpiration(); got != 300*time.Millisecond {
+		t.Errorf("default expiration = %v", got)
+	}
+	SetConfig(ConfigMemoizerExpiration, "50")
+	t.Cleanup(func() { SetConfig(ConfigMemoizerExpiration, "") })
+	if got := DefaultExpiration(); got != 50*time.Millisecond {
+		t.Errorf("configured expiration = %v, want 50ms", got)
+	}
+}
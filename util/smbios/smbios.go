@@ -0,0 +1,217 @@
+/*
+ * Copyright 2016-2024 The OSHI Project Contributors
+ * SPDX-License-Identifier: MIT
+ */
+
+// Package smbios decodes the SMBIOS (DMI) structure table firmware provides to describe the
+// hardware, as read from /sys/firmware/dmi/tables on linux or GetSystemFirmwareTable on windows.
+package smbios
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	TypeBIOS                     = 0
+	TypeSystem                   = 1
+	TypeBaseboard                = 2
+	TypeChassis                  = 3
+	TypeProcessor                = 4
+	TypeCache                    = 7
+	TypeOEMStrings               = 11
+	TypePhysicalMemoryArray      = 16
+	TypeMemoryDevice             = 17
+	TypeMemoryArrayMappedAddress = 19
+	TypeEndOfTable               = 127
+
+	headerLength = 4
+)
+
+var (
+	anchor2 = []byte("_SM_")
+	anchor3 = []byte("_SM3_")
+
+	ErrInvalidEntryPoint = errors.New("smbios: invalid entry point")
+)
+
+// EntryPoint locates the structure table in memory and gives the SMBIOS version it conforms to.
+type EntryPoint struct {
+	Major, Minor, Revision int
+	TableAddress           uint64
+	// TableLength is the exact length of the table for 2.x entry points and its maximum size for 3.x.
+	TableLength uint32
+	// StructureCount is the number of structures of the table, 0 for 3.x entry points.
+	StructureCount int
+}
+
+func checksum(b []byte) bool {
+	var sum byte
+	for _, v := range b {
+		sum += v
+	}
+	return sum == 0
+}
+
+// ParseEntryPoint decodes a 32 bit (_SM_) or 64 bit (_SM3_) entry point structure.
+func ParseEntryPoint(b []byte) (EntryPoint, error) {
+	var ep EntryPoint
+	switch {
+	case bytes.HasPrefix(b, anchor3):
+		if len(b) < 0x18 || int(b[0x06]) > len(b) || !checksum(b[:b[0x06]]) {
+			return ep, ErrInvalidEntryPoint
+		}
+		ep.Major, ep.Minor, ep.Revision = int(b[0x07]), int(b[0x08]), int(b[0x09])
+		ep.TableLength = binary.LittleEndian.Uint32(b[0x0C:])
+		ep.TableAddress = binary.LittleEndian.Uint64(b[0x10:])
+	case bytes.HasPrefix(b, anchor2):
+		if len(b) < 0x1F || int(b[0x05]) > len(b) || !checksum(b[:b[0x05]]) {
+			return ep, ErrInvalidEntryPoint
+		}
+		ep.Major, ep.Minor = int(b[0x06]), int(b[0x07])
+		// the intermediate _DMI_ structure follows at 0x10
+		ep.TableLength = uint32(binary.LittleEndian.Uint16(b[0x16:]))
+		ep.TableAddress = uint64(binary.LittleEndian.Uint32(b[0x18:]))
+		ep.StructureCount = int(binary.LittleEndian.Uint16(b[0x1C:]))
+	default:
+		return ep, ErrInvalidEntryPoint
+	}
+	return ep, nil
+}
+
+// Structure is a raw record of the table: its formatted area, header included, and its string set.
+type Structure struct {
+	Type      uint8
+	Handle    uint16
+	Formatted []byte
+	Strings   []string
+}
+
+// Byte returns the formatted byte at off, or 0 if the structure is too short.
+func (s Structure) Byte(off int) uint8 {
+	if off >= len(s.Formatted) {
+		return 0
+	}
+	return s.Formatted[off]
+}
+
+func (s Structure) Word(off int) uint16 {
+	if off+2 > len(s.Formatted) {
+		return 0
+	}
+	return binary.LittleEndian.Uint16(s.Formatted[off:])
+}
+
+func (s Structure) DWord(off int) uint32 {
+	if off+4 > len(s.Formatted) {
+		return 0
+	}
+	return binary.LittleEndian.Uint32(s.Formatted[off:])
+}
+
+func (s Structure) QWord(off int) uint64 {
+	if off+8 > len(s.Formatted) {
+		return 0
+	}
+	return binary.LittleEndian.Uint64(s.Formatted[off:])
+}
+
+// String resolves the 1-based string number stored at off, empty if there is none.
+func (s Structure) String(off int) string {
+	idx := int(s.Byte(off))
+	if idx == 0 || idx > len(s.Strings) {
+		return ""
+	}
+	return strings.TrimSpace(s.Strings[idx-1])
+}
+
+// ParseStructures splits a structure table into its records, up to the end of table record.
+func ParseStructures(table []byte) []Structure {
+	structs := make([]Structure, 0)
+	for off := 0; off+headerLength <= len(table); {
+		typ := table[off]
+		length := int(table[off+1])
+		if length < headerLength || off+length > len(table) {
+			break
+		}
+		s := Structure{
+			Type:      typ,
+			Handle:    binary.LittleEndian.Uint16(table[off+2:]),
+			Formatted: table[off : off+length],
+		}
+		// the string set follows the formatted area and ends with a double null
+		end := off + length
+		for end+1 < len(table) && (table[end] != 0 || table[end+1] != 0) {
+			end++
+		}
+		if end > off+length {
+			s.Strings = strings.Split(string(table[off+length:end]), "\x00")
+		}
+		structs = append(structs, s)
+		if typ == TypeEndOfTable {
+			break
+		}
+		off = end + 2
+	}
+	return structs
+}
+
+// Table is a decoded structure table along with the SMBIOS version, which some fields depend on.
+type Table struct {
+	Major, Minor int
+	Structures   []Structure
+}
+
+// Parse decodes a structure table, the version is read from the entry point if one is given.
+// An invalid entry point is reported as ErrInvalidEntryPoint along with the decoded structures.
+func Parse(entryPoint, table []byte) (Table, error) {
+	t := Table{Structures: ParseStructures(table)}
+	if len(entryPoint) != 0 {
+		ep, err := ParseEntryPoint(entryPoint)
+		if err != nil {
+			return t, err
+		}
+		t.Major, t.Minor = ep.Major, ep.Minor
+	}
+	return t, nil
+}
+
+// ParseRawSMBIOSData decodes the RawSMBIOSData structure GetSystemFirmwareTable returns for the
+// RSMB provider: a calling method byte, the major and minor version, the dmi revision and the
+// length of the table that follows.
+func ParseRawSMBIOSData(b []byte) (Table, error) {
+	if len(b) < 8 {
+		return Table{}, fmt.Errorf("smbios: expected at least 8 bytes of raw data, got %d", len(b))
+	}
+	length := int(binary.LittleEndian.Uint32(b[4:]))
+	if 8+length > len(b) {
+		return Table{}, fmt.Errorf("smbios: table of %d bytes exceeds the %d bytes of raw data", length, len(b)-8)
+	}
+	return Table{
+		Major:      int(b[1]),
+		Minor:      int(b[2]),
+		Structures: ParseStructures(b[8 : 8+length]),
+	}, nil
+}
+
+// AtLeast reports whether the table conforms to at least the given SMBIOS version. Tables of an
+// unknown version are assumed to be current.
+func (t Table) AtLeast(major, minor int) bool {
+	if t.Major == 0 {
+		return true
+	}
+	return t.Major > major || (t.Major == major && t.Minor >= minor)
+}
+
+func (t Table) ofType(typ uint8) []Structure {
+	structs := make([]Structure, 0)
+	for _, s := range t.Structures {
+		if s.Type == typ {
+			structs = append(structs, s)
+		}
+	}
+	return structs
+}
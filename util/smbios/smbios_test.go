@@ -0,0 +1,275 @@
+/*
+ * Copyright 2016-2024 The OSHI Project Contributors
+ * SPDX-License-Identifier: MIT
+ */
+
+package smbios
+
+import (
+	"encoding/binary"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func readDump(t *testing.T, name string) []byte {
+	t.Helper()
+	b, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func parseDump(t *testing.T, version string) Table {
+	t.Helper()
+	table, err := Parse(readDump(t, "smbios_entry_point-"+version), readDump(t, "DMI-"+version))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return table
+}
+
+func TestParseEntryPoint(t *testing.T) {
+	tests := []struct {
+		name string
+		want EntryPoint
+	}{
+		{"smbios_entry_point-2.5", EntryPoint{Major: 2, Minor: 5, TableAddress: 0x000F0800, TableLength: 394, StructureCount: 8}},
+		{"smbios_entry_point-3.3", EntryPoint{Major: 3, Minor: 3, TableAddress: 0x7A4B5000, TableLength: 586}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ep, err := ParseEntryPoint(readDump(t, tt.name))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if ep != tt.want {
+				t.Errorf("entry point = %+v, want %+v", ep, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseEntryPointInvalid(t *testing.T) {
+	badChecksum := readDump(t, "smbios_entry_point-3.3")
+	badChecksum[0x08]++
+	tests := []struct {
+		name string
+		b    []byte
+	}{
+		{"bad checksum", badChecksum},
+		{"truncated", readDump(t, "smbios_entry_point-2.5")[:0x10]},
+		{"no anchor", []byte("_DMI_ and something else entirely")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseEntryPoint(tt.b); !errors.Is(err, ErrInvalidEntryPoint) {
+				t.Errorf("error = %v, want %v", err, ErrInvalidEntryPoint)
+			}
+		})
+	}
+}
+
+func TestParseKeepsStructuresOfInvalidEntryPoint(t *testing.T) {
+	entryPoint := readDump(t, "smbios_entry_point-2.5")
+	entryPoint[0x04]++
+	table, err := Parse(entryPoint, readDump(t, "DMI-2.5"))
+	if !errors.Is(err, ErrInvalidEntryPoint) {
+		t.Fatalf("error = %v, want %v", err, ErrInvalidEntryPoint)
+	}
+	if table.Major != 0 || len(table.Structures) != 8 {
+		t.Errorf("got version %d.%d and %d structures, want no version and 8", table.Major, table.Minor, len(table.Structures))
+	}
+}
+
+func TestParseStructures(t *testing.T) {
+	structs := ParseStructures(readDump(t, "DMI-3.3"))
+	types := make([]uint8, 0, len(structs))
+	for _, s := range structs {
+		types = append(types, s.Type)
+	}
+	if want := []uint8{0, 1, 4, 17, 17, 126, 127}; !reflect.DeepEqual(types, want) {
+		t.Errorf("types = %v, want %v", types, want)
+	}
+	// a structure without strings ends with a double null
+	if inactive := structs[5]; inactive.Handle != 0x0044 || len(inactive.Strings) != 0 {
+		t.Errorf("inactive structure = %+v", inactive)
+	}
+	// structures after the end of table are ignored
+	table := append(readDump(t, "DMI-3.3"), 0x01, 0x1B, 0x00, 0x10)
+	if got := len(ParseStructures(table)); got != len(structs) {
+		t.Errorf("got %d structures past the end of table, want %d", got, len(structs))
+	}
+	// a truncated table keeps the complete structures
+	if got := len(ParseStructures(readDump(t, "DMI-3.3")[:100])); got != 1 {
+		t.Errorf("got %d structures of a truncated table, want 1", got)
+	}
+}
+
+func TestStrings(t *testing.T) {
+	s := Structure{Formatted: []byte{0, 7, 0, 0, 1, 3, 0}, Strings: []string{"Dell Inc.   ", "unused", " A12"}}
+	tests := []struct {
+		off  int
+		want string
+	}{
+		{4, "Dell Inc."},
+		{5, "A12"},
+		// string number 0 means no string
+		{6, ""},
+		// beyond the formatted area
+		{7, ""},
+	}
+	for _, tt := range tests {
+		if got := s.String(tt.off); got != tt.want {
+			t.Errorf("String(%#x) = %q, want %q", tt.off, got, tt.want)
+		}
+	}
+	if got := (Structure{Formatted: []byte{0, 5, 0, 0, 2}, Strings: []string{"one"}}).String(4); got != "" {
+		t.Errorf("out of range string number = %q, want none", got)
+	}
+}
+
+func TestUUIDByteOrder(t *testing.T) {
+	tests := []struct {
+		version string
+		want    string
+	}{
+		// before 2.6 the UUID is stored in network byte order
+		{"2.5", "00112233-4455-6677-8899-aabbccddeeff"},
+		{"3.3", "33221100-5544-7766-8899-aabbccddeeff"},
+	}
+	for _, tt := range tests {
+		systems := parseDump(t, tt.version).System()
+		if len(systems) != 1 || systems[0].UUID != tt.want {
+			t.Errorf("%s: systems = %+v, want UUID %s", tt.version, systems, tt.want)
+		}
+	}
+	// an unknown version is assumed to be current
+	table := Table{Structures: ParseStructures(readDump(t, "DMI-2.5"))}
+	if uuid := table.System()[0].UUID; uuid != tests[1].want {
+		t.Errorf("UUID of an unknown version = %s, want %s", uuid, tests[1].want)
+	}
+	if uuid := decodeUUID(make([]byte, 16), true); uuid != "" {
+		t.Errorf("UUID of zeroes = %s, want none", uuid)
+	}
+}
+
+func TestTable25(t *testing.T) {
+	table := parseDump(t, "2.5")
+	wantBIOS := []BIOS{{
+		Vendor: "Dell Inc.", Version: "A12", ReleaseDate: "03/21/2011",
+		StartingSegment: 0xE800, ROMSize: 1 << 20, Characteristics: 0x0BCBD98880, CharacteristicsExt: 0x0180,
+		MajorRelease: 1, MinorRelease: 4, ECMajorRelease: 0xFF, ECMinorRelease: 0xFF,
+	}}
+	if bios := table.BIOS(); !reflect.DeepEqual(bios, wantBIOS) {
+		t.Errorf("BIOS = %+v, want %+v", bios, wantBIOS)
+	} else if bios[0].UEFI() || FormatDate(bios[0].ReleaseDate) != "2011-03-21" {
+		t.Errorf("UEFI = %t, release date %s", bios[0].UEFI(), FormatDate(bios[0].ReleaseDate))
+	}
+	system := table.System()[0]
+	if system.Manufacturer != "Dell Inc." || system.ProductName != "OptiPlex 790" || system.SerialNumber != "7XQ4F5J" ||
+		system.SKUNumber != "SKU123" || system.Family != "OptiPlex" || system.WakeUpType != 6 {
+		t.Errorf("system = %+v", system)
+	}
+	wantBoard := []Baseboard{{
+		Manufacturer: "Dell Inc.", Product: "0HY9JP", Version: "A01", SerialNumber: "/7XQ4F5J/CN7016",
+		FeatureFlags: 0x09, ChassisHandle: 0x0300, BoardType: 0x0A,
+	}}
+	if boards := table.Baseboard(); !reflect.DeepEqual(boards, wantBoard) {
+		t.Errorf("baseboard = %+v, want %+v", boards, wantBoard)
+	}
+	if oem := table.OEMStrings(); !reflect.DeepEqual(oem, []string{"Dell System", "5[0000]"}) {
+		t.Errorf("OEM strings = %q", oem)
+	}
+
+	dimms := table.MemoryDevices()
+	if len(dimms) != 3 {
+		t.Fatalf("got %d memory devices, want 3", len(dimms))
+	}
+	want := MemoryDevice{
+		ArrayHandle: 0x1000, TotalWidth: 64, DataWidth: 64, Size: 4 << 30, FormFactor: 0x09,
+		DeviceLocator: "DIMM_A", BankLocator: "BANK 0", Type: 0x1A, TypeDetail: 0x0080, Speed: 1333,
+		Manufacturer: "80CE000080CE", SerialNumber: "8516F1B6", AssetTag: "0123456", PartNumber: "M378B5273CH0-CH9",
+		Rank: 2,
+	}
+	if dimms[0] != want {
+		t.Errorf("memory device = %+v, want %+v", dimms[0], want)
+	}
+	// bit 15 gives the size in kilobytes, 0 is an empty slot
+	if dimms[1].Size != 512<<10 || dimms[2].Size != 0 {
+		t.Errorf("sizes = %d, %d", dimms[1].Size, dimms[2].Size)
+	}
+}
+
+func TestTable33(t *testing.T) {
+	table := parseDump(t, "3.3")
+	bios := table.BIOS()
+	if len(bios) != 1 || bios[0].ROMSize != 32<<20 || !bios[0].UEFI() {
+		t.Errorf("BIOS = %+v", bios)
+	}
+	if table.System()[0].Family != "To be filled by O.E.M." {
+		t.Errorf("system = %+v", table.System()[0])
+	}
+	wantProc := Processor{
+		SocketDesignation: "CPU0", Type: 3, Family: 0x0118, Manufacturer: "Ampere(R)", ID: 0xBFEBFBFF000A0671,
+		Version: "Ampere(R) Altra(R) Processor", Voltage: 0x8A, ExternalClock: 100, MaxSpeed: 5000, CurrentSpeed: 3600,
+		Status: 0x41, Upgrade: 0x01, L1CacheHandle: 0x0700, L2CacheHandle: 0x0701, L3CacheHandle: 0x0702,
+		CoreCount: 256, CoreEnabled: 256, ThreadCount: 512, Characteristics: 0x00FC,
+	}
+	if procs := table.Processors(); !reflect.DeepEqual(procs, []Processor{wantProc}) {
+		t.Errorf("processors = %+v, want %+v", procs, wantProc)
+	}
+
+	dimms := table.MemoryDevices()
+	if len(dimms) != 2 {
+		t.Fatalf("got %d memory devices, want 2", len(dimms))
+	}
+	// extended size in megabytes and extended speeds in MT/s
+	if dimms[0].Size != 64<<30 || dimms[0].Speed != 8000 || dimms[0].ConfiguredSpeed != 8000 || dimms[0].Type != 0x22 {
+		t.Errorf("memory device = %+v", dimms[0])
+	}
+	if dimms[0].PartNumber != "M321R8GA0BB0-CQKZJ" || dimms[0].ConfiguredVoltage != 1200 {
+		t.Errorf("memory device = %+v", dimms[0])
+	}
+	if dimms[1].Size != 0 || dimms[1].Manufacturer != "" {
+		t.Errorf("empty slot = %+v", dimms[1])
+	}
+}
+
+func TestParseRawSMBIOSData(t *testing.T) {
+	table := readDump(t, "DMI-3.3")
+	raw := make([]byte, 8, 8+len(table))
+	raw[1], raw[2] = 3, 3
+	binary.LittleEndian.PutUint32(raw[4:], uint32(len(table)))
+	raw = append(raw, table...)
+	parsed, err := ParseRawSMBIOSData(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(parsed, parseDump(t, "3.3")) {
+		t.Errorf("raw data table differs from the dumped one")
+	}
+	if _, err := ParseRawSMBIOSData(raw[:len(raw)-1]); err == nil {
+		t.Errorf("expected an error for a truncated table")
+	}
+	if _, err := ParseRawSMBIOSData(raw[:4]); err == nil {
+		t.Errorf("expected an error for a truncated header")
+	}
+}
+
+func TestFormatDate(t *testing.T) {
+	tests := map[string]string{
+		"08/12/2022": "2022-08-12",
+		"2022-08-12": "2022-08-12",
+		"08/12/22":   "08/12/22",
+		"":           "",
+	}
+	for date, want := range tests {
+		if got := FormatDate(date); got != want {
+			t.Errorf("FormatDate(%q) = %q, want %q", date, got, want)
+		}
+	}
+}
@@ -0,0 +1,427 @@
+/*
+ * Copyright 2016-2024 The OSHI Project Contributors
+ * SPDX-License-Identifier: MIT
+ */
+
+package smbios
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	unknownSize     = 0xFFFF
+	extendedSize    = 0x7FFF
+	extendedSpeed   = 0xFFFF
+	extendedAddress = 0xFFFFFFFF
+)
+
+// BIOS is a type 0 structure.
+type BIOS struct {
+	Vendor, Version, ReleaseDate string
+	StartingSegment              uint16
+	// ROMSize is in bytes.
+	ROMSize         int64
+	Characteristics uint64
+	// CharacteristicsExt holds the two extension bytes, the second one first.
+	CharacteristicsExt uint16
+	// MajorRelease and MinorRelease are the system BIOS release, ECMajorRelease and ECMinorRelease that of
+	// the embedded controller firmware, 0xFF if not given.
+	MajorRelease, MinorRelease, ECMajorRelease, ECMinorRelease int
+}
+
+// UEFI reports whether the firmware supports UEFI.
+func (b BIOS) UEFI() bool {
+	return b.CharacteristicsExt&0x0800 != 0
+}
+
+// System is a type 1 structure.
+type System struct {
+	Manufacturer, ProductName, Version, SerialNumber string
+	// UUID is empty if the system has none or it is not set.
+	UUID              string
+	WakeUpType        int
+	SKUNumber, Family string
+}
+
+// Baseboard is a type 2 structure.
+type Baseboard struct {
+	Manufacturer, Product, Version, SerialNumber, AssetTag string
+	FeatureFlags                                           int
+	LocationInChassis                                      string
+	ChassisHandle                                          uint16
+	BoardType                                              int
+}
+
+// Chassis is a type 3 structure.
+type Chassis struct {
+	Manufacturer                                          string
+	Type                                                  int
+	Lock                                                  bool
+	Version, SerialNumber, AssetTag                       string
+	BootUpState, PowerSupplyState, ThermalState, Security int
+	Height, NumberOfPowerCords                            int
+}
+
+// Processor is a type 4 structure.
+type Processor struct {
+	SocketDesignation string
+	Type, Family      int
+	Manufacturer      string
+	ID                uint64
+	Version           string
+	Voltage           int
+	// ExternalClock, MaxSpeed and CurrentSpeed are in MHz.
+	ExternalClock, MaxSpeed, CurrentSpeed       int
+	Status, Upgrade                             int
+	L1CacheHandle, L2CacheHandle, L3CacheHandle uint16
+	SerialNumber, AssetTag, PartNumber          string
+	CoreCount, CoreEnabled, ThreadCount         int
+	Characteristics                             uint16
+}
+
+// Cache is a type 7 structure.
+type Cache struct {
+	SocketDesignation string
+	Level             int
+	Enabled           bool
+	// Location is 0 for internal, 1 for external.
+	Location, OperationalMode int
+	// MaximumSize and InstalledSize are in bytes.
+	MaximumSize, InstalledSize                                 int64
+	Speed, ErrorCorrectionType, SystemCacheType, Associativity int
+}
+
+// PhysicalMemoryArray is a type 16 structure.
+type PhysicalMemoryArray struct {
+	Location, Use, ErrorCorrection int
+	// MaximumCapacity is in bytes.
+	MaximumCapacity        int64
+	ErrorInformationHandle uint16
+	NumberOfDevices        int
+}
+
+// MemoryDevice is a type 17 structure.
+type MemoryDevice struct {
+	ArrayHandle           uint16
+	TotalWidth, DataWidth int
+	// Size is in bytes, 0 for an empty slot and -1 if unknown.
+	Size                       int64
+	FormFactor, DeviceSet      int
+	DeviceLocator, BankLocator string
+	Type, TypeDetail           int
+	// Speed and ConfiguredSpeed are in MT/s.
+	Speed                                            int64
+	Manufacturer, SerialNumber, AssetTag, PartNumber string
+	Rank                                             int
+	ConfiguredSpeed                                  int64
+	// ConfiguredVoltage is in millivolts.
+	ConfiguredVoltage int
+}
+
+// MemoryArrayMappedAddress is a type 19 structure.
+type MemoryArrayMappedAddress struct {
+	// StartingAddress and EndingAddress are byte addresses, the ending address included.
+	StartingAddress, EndingAddress uint64
+	ArrayHandle                    uint16
+	PartitionWidth                 int
+}
+
+// FormatDate converts the mm/dd/yyyy dates of SMBIOS strings to yyyy-mm-dd, other formats are returned as is.
+func FormatDate(date string) string {
+	parts := strings.Split(date, "/")
+	if len(parts) != 3 || len(parts[2]) != 4 {
+		return date
+	}
+	return fmt.Sprintf("%s-%s-%s", parts[2], parts[0], parts[1])
+}
+
+func decodeUUID(b []byte, littleEndian bool) string {
+	if len(b) != 16 {
+		return ""
+	}
+	allSet, allClear := true, true
+	for _, v := range b {
+		allSet = allSet && v == 0xFF
+		allClear = allClear && v == 0
+	}
+	if allSet || allClear {
+		return ""
+	}
+	if littleEndian {
+		// SMBIOS 2.6 stores the time_low, time_mid and time_hi_and_version fields little endian
+		return fmt.Sprintf("%02x%02x%02x%02x-%02x%02x-%02x%02x-%02x%02x-%x",
+			b[3], b[2], b[1], b[0], b[5], b[4], b[7], b[6], b[8], b[9], b[10:])
+	}
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
+
+// cacheSize decodes a cache size word: 1K granularity, or 64K if bit 15 is set
+func cacheSize(size uint32, granularityBit uint32) int64 {
+	if size&granularityBit != 0 {
+		return int64(size&^granularityBit) << 16
+	}
+	return int64(size) << 10
+}
+
+func (t Table) BIOS() []BIOS {
+	records := make([]BIOS, 0)
+	for _, s := range t.ofType(TypeBIOS) {
+		romSize := (int64(s.Byte(0x09)) + 1) << 16
+		if s.Byte(0x09) == 0xFF && len(s.Formatted) > 0x19 {
+			// extended size in MB, or GB if bit 14 is set
+			ext := s.Word(0x18)
+			romSize = int64(ext&0x3FFF) << 20
+			if ext&0x4000 != 0 {
+				romSize <<= 10
+			}
+		}
+		records = append(records, BIOS{
+			Vendor:             s.String(0x04),
+			Version:            s.String(0x05),
+			StartingSegment:    s.Word(0x06),
+			ReleaseDate:        s.String(0x08),
+			ROMSize:            romSize,
+			Characteristics:    s.QWord(0x0A),
+			CharacteristicsExt: uint16(s.Byte(0x13))<<8 | uint16(s.Byte(0x12)),
+			MajorRelease:       int(s.Byte(0x14)),
+			MinorRelease:       int(s.Byte(0x15)),
+			ECMajorRelease:     int(s.Byte(0x16)),
+			ECMinorRelease:     int(s.Byte(0x17)),
+		})
+	}
+	return records
+}
+
+func (t Table) System() []System {
+	records := make([]System, 0)
+	for _, s := range t.ofType(TypeSystem) {
+		var uuid string
+		if len(s.Formatted) >= 0x18 {
+			uuid = decodeUUID(s.Formatted[0x08:0x18], t.AtLeast(2, 6))
+		}
+		records = append(records, System{
+			Manufacturer: s.String(0x04),
+			ProductName:  s.String(0x05),
+			Version:      s.String(0x06),
+			SerialNumber: s.String(0x07),
+			UUID:         uuid,
+			WakeUpType:   int(s.Byte(0x18)),
+			SKUNumber:    s.String(0x19),
+			Family:       s.String(0x1A),
+		})
+	}
+	return records
+}
+
+func (t Table) Baseboard() []Baseboard {
+	records := make([]Baseboard, 0)
+	for _, s := range t.ofType(TypeBaseboard) {
+		records = append(records, Baseboard{
+			Manufacturer:      s.String(0x04),
+			Product:           s.String(0x05),
+			Version:           s.String(0x06),
+			SerialNumber:      s.String(0x07),
+			AssetTag:          s.String(0x08),
+			FeatureFlags:      int(s.Byte(0x09)),
+			LocationInChassis: s.String(0x0A),
+			ChassisHandle:     s.Word(0x0B),
+			BoardType:         int(s.Byte(0x0D)),
+		})
+	}
+	return records
+}
+
+func (t Table) Chassis() []Chassis {
+	records := make([]Chassis, 0)
+	for _, s := range t.ofType(TypeChassis) {
+		records = append(records, Chassis{
+			Manufacturer:       s.String(0x04),
+			Type:               int(s.Byte(0x05) & 0x7F),
+			Lock:               s.Byte(0x05)&0x80 != 0,
+			Version:            s.String(0x06),
+			SerialNumber:       s.String(0x07),
+			AssetTag:           s.String(0x08),
+			BootUpState:        int(s.Byte(0x09)),
+			PowerSupplyState:   int(s.Byte(0x0A)),
+			ThermalState:       int(s.Byte(0x0B)),
+			Security:           int(s.Byte(0x0C)),
+			Height:             int(s.Byte(0x11)),
+			NumberOfPowerCords: int(s.Byte(0x12)),
+		})
+	}
+	return records
+}
+
+func (t Table) Processors() []Processor {
+	records := make([]Processor, 0)
+	for _, s := range t.ofType(TypeProcessor) {
+		family := int(s.Byte(0x06))
+		if family == 0xFE {
+			family = int(s.Word(0x28))
+		}
+		// counts above 255 are given in the 3.0 words
+		cores, enabled, threads := int(s.Byte(0x23)), int(s.Byte(0x24)), int(s.Byte(0x25))
+		if cores == 0xFF && len(s.Formatted) >= 0x30 {
+			cores = int(s.Word(0x2A))
+		}
+		if enabled == 0xFF && len(s.Formatted) >= 0x30 {
+			enabled = int(s.Word(0x2C))
+		}
+		if threads == 0xFF && len(s.Formatted) >= 0x30 {
+			threads = int(s.Word(0x2E))
+		}
+		records = append(records, Processor{
+			SocketDesignation: s.String(0x04),
+			Type:              int(s.Byte(0x05)),
+			Family:            family,
+			Manufacturer:      s.String(0x07),
+			ID:                s.QWord(0x08),
+			Version:           s.String(0x10),
+			Voltage:           int(s.Byte(0x11)),
+			ExternalClock:     int(s.Word(0x12)),
+			MaxSpeed:          int(s.Word(0x14)),
+			CurrentSpeed:      int(s.Word(0x16)),
+			Status:            int(s.Byte(0x18)),
+			Upgrade:           int(s.Byte(0x19)),
+			L1CacheHandle:     s.Word(0x1A),
+			L2CacheHandle:     s.Word(0x1C),
+			L3CacheHandle:     s.Word(0x1E),
+			SerialNumber:      s.String(0x20),
+			AssetTag:          s.String(0x21),
+			PartNumber:        s.String(0x22),
+			CoreCount:         cores,
+			CoreEnabled:       enabled,
+			ThreadCount:       threads,
+			Characteristics:   s.Word(0x26),
+		})
+	}
+	return records
+}
+
+func (t Table) Caches() []Cache {
+	records := make([]Cache, 0)
+	for _, s := range t.ofType(TypeCache) {
+		config := s.Word(0x05)
+		maximum := cacheSize(uint32(s.Word(0x07)), 0x8000)
+		installed := cacheSize(uint32(s.Word(0x09)), 0x8000)
+		// the 3.1 dwords hold sizes of 2 GB and more
+		if s.Word(0x07) == 0xFFFF && len(s.Formatted) >= 0x1B {
+			maximum = cacheSize(s.DWord(0x13), 0x80000000)
+			installed = cacheSize(s.DWord(0x17), 0x80000000)
+		}
+		records = append(records, Cache{
+			SocketDesignation:   s.String(0x04),
+			Level:               int(config&0x07) + 1,
+			Enabled:             config&0x80 != 0,
+			Location:            int(config>>5) & 0x03,
+			OperationalMode:     int(config>>8) & 0x03,
+			MaximumSize:         maximum,
+			InstalledSize:       installed,
+			Speed:               int(s.Byte(0x0F)),
+			ErrorCorrectionType: int(s.Byte(0x10)),
+			SystemCacheType:     int(s.Byte(0x11)),
+			Associativity:       int(s.Byte(0x12)),
+		})
+	}
+	return records
+}
+
+// OEMStrings returns the strings of the type 11 structures.
+func (t Table) OEMStrings() []string {
+	strs := make([]string, 0)
+	for _, s := range t.ofType(TypeOEMStrings) {
+		for i := 0; i < int(s.Byte(0x04)) && i < len(s.Strings); i++ {
+			strs = append(strs, s.Strings[i])
+		}
+	}
+	return strs
+}
+
+func (t Table) PhysicalMemoryArrays() []PhysicalMemoryArray {
+	records := make([]PhysicalMemoryArray, 0)
+	for _, s := range t.ofType(TypePhysicalMemoryArray) {
+		capacity := int64(s.DWord(0x07)) << 10
+		if s.DWord(0x07) == 0x80000000 {
+			capacity = int64(s.QWord(0x0F))
+		}
+		records = append(records, PhysicalMemoryArray{
+			Location:               int(s.Byte(0x04)),
+			Use:                    int(s.Byte(0x05)),
+			ErrorCorrection:        int(s.Byte(0x06)),
+			MaximumCapacity:        capacity,
+			ErrorInformationHandle: s.Word(0x0B),
+			NumberOfDevices:        int(s.Word(0x0D)),
+		})
+	}
+	return records
+}
+
+// memoryDeviceSize returns the size of a memory device in bytes, -1 if unknown
+func memoryDeviceSize(s Structure) int64 {
+	size := s.Word(0x0C)
+	switch {
+	case size == unknownSize:
+		return -1
+	case size == extendedSize:
+		return int64(s.DWord(0x1C)&0x7FFFFFFF) << 20
+	case size&0x8000 != 0:
+		return int64(size&0x7FFF) << 10
+	default:
+		return int64(size) << 20
+	}
+}
+
+func (t Table) MemoryDevices() []MemoryDevice {
+	records := make([]MemoryDevice, 0)
+	for _, s := range t.ofType(TypeMemoryDevice) {
+		speed := int64(s.Word(0x15))
+		if speed == extendedSpeed {
+			speed = int64(s.DWord(0x54))
+		}
+		configuredSpeed := int64(s.Word(0x20))
+		if configuredSpeed == extendedSpeed {
+			configuredSpeed = int64(s.DWord(0x58))
+		}
+		records = append(records, MemoryDevice{
+			ArrayHandle:       s.Word(0x04),
+			TotalWidth:        int(s.Word(0x08)),
+			DataWidth:         int(s.Word(0x0A)),
+			Size:              memoryDeviceSize(s),
+			FormFactor:        int(s.Byte(0x0E)),
+			DeviceSet:         int(s.Byte(0x0F)),
+			DeviceLocator:     s.String(0x10),
+			BankLocator:       s.String(0x11),
+			Type:              int(s.Byte(0x12)),
+			TypeDetail:        int(s.Word(0x13)),
+			Speed:             speed,
+			Manufacturer:      s.String(0x17),
+			SerialNumber:      s.String(0x18),
+			AssetTag:          s.String(0x19),
+			PartNumber:        s.String(0x1A),
+			Rank:              int(s.Byte(0x1B) & 0x0F),
+			ConfiguredSpeed:   configuredSpeed,
+			ConfiguredVoltage: int(s.Word(0x26)),
+		})
+	}
+	return records
+}
+
+func (t Table) MemoryArrayMappedAddresses() []MemoryArrayMappedAddress {
+	records := make([]MemoryArrayMappedAddress, 0)
+	for _, s := range t.ofType(TypeMemoryArrayMappedAddress) {
+		start := uint64(s.DWord(0x04)) << 10
+		end := uint64(s.DWord(0x08))<<10 | 0x3FF
+		if s.DWord(0x04) == extendedAddress {
+			start, end = s.QWord(0x0F), s.QWord(0x17)
+		}
+		records = append(records, MemoryArrayMappedAddress{
+			StartingAddress: start,
+			EndingAddress:   end,
+			ArrayHandle:     s.Word(0x0C),
+			PartitionWidth:  int(s.Byte(0x0E)),
+		})
+	}
+	return records
+}
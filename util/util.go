@@ -11,6 +11,7 @@ import (
 	"regexp"
 	"runtime"
 	"strconv"
+	"strings"
 )
 
 var (
@@ -87,3 +88,25 @@ func ParseHertz(s string) int64 {
 	val = val * multiplier
 	return int64(val)
 }
+
+// ParseIntList parses sysfs style lists such as "0-3,8,10-11" into their values.
+func ParseIntList(s string) []int {
+	res := make([]int, 0)
+	for _, part := range strings.Split(strings.TrimSpace(s), ",") {
+		from, to, isRange := strings.Cut(part, "-")
+		lo, err := strconv.Atoi(strings.TrimSpace(from))
+		if err != nil {
+			continue
+		}
+		hi := lo
+		if isRange {
+			if hi, err = strconv.Atoi(strings.TrimSpace(to)); err != nil {
+				continue
+			}
+		}
+		for i := lo; i <= hi; i++ {
+			res = append(res, i)
+		}
+	}
+	return res
+}
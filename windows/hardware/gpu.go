@@ -1,3 +1,5 @@
+//go:build windows
+
 /*
  * Copyright 2016-2024 The OSHI Project Contributors
  * SPDX-License-Identifier: MIT
@@ -10,6 +12,7 @@ import (
 	"fmt"
 	"golang.org/x/sys/windows"
 	"golang.org/x/sys/windows/registry"
+	"goshi/internal/ids"
 	"goshi/sysinfo/hardware"
 	"goshi/util"
 	"goshi/windows/internal"
@@ -24,6 +27,8 @@ const (
 
 var (
 	gpuRegex = regexp.MustCompile(`.*(?:VID|VEN)_([[:xdigit:]]{4})&(?:PID|DEV)_([[:xdigit:]]{4})(.*)\\(.*)`)
+	// MatchingDeviceId of the display registry keys, such as pci\ven_10de&dev_1c82&subsys_11bf10de
+	matchingDeviceRegex = regexp.MustCompile(`(?i)VEN_([[:xdigit:]]{4})&DEV_([[:xdigit:]]{4})`)
 )
 
 type WindowsGraphicsCard struct {
@@ -65,8 +70,19 @@ func parseGPUDeviceID(devId string) []string {
 	return nil
 }
 
+// pciVendorName appends the vendor id to its pci.ids name, or to the name reported by windows for
+// vendors missing from pci.ids. Windows reports the provider of the driver, which is not always the
+// vendor of the chip.
+func pciVendorName(name, vendorId string) string {
+	name = util.StringValueOrDefault(ids.PciVendor(vendorId), name)
+	if len(name) == 0 {
+		return vendorId
+	}
+	return fmt.Sprintf("%s (%s)", name, vendorId)
+}
+
 func wmiGraphicsCards() ([]hardware.GraphicsCard, error) {
-	if internal.VistaOrGreater {
+	if !internal.VistaOrGreater {
 		return nil, errors.New("wmi: gpu query requires vista or greater")
 	}
 	gpus := make([]hardware.GraphicsCard, 0)
@@ -82,14 +98,11 @@ func wmiGraphicsCards() ([]hardware.GraphicsCard, error) {
 		} else {
 			deviceId = matches[1]
 		}
-		// can be refactored
 		vendor := v.AdapterCompatibility
+		name := v.Name
 		if matches != nil {
-			if len(vendor) == 0 {
-				deviceId = matches[0]
-			} else {
-				vendor = fmt.Sprintf("%s (%s)", vendor, matches[0])
-			}
+			vendor = pciVendorName(vendor, matches[0])
+			name = util.StringValueOrDefault(name, ids.PciDevice(matches[0], matches[1]))
 		}
 		versionInfo := v.DriverVersion
 		if len(versionInfo) != 0 {
@@ -99,7 +112,7 @@ func wmiGraphicsCards() ([]hardware.GraphicsCard, error) {
 		}
 		vram := v.AdapterRAM
 		gpu := WindowsGraphicsCard{
-			name:        util.StringValueOrDefault(v.Name, util.Unknown),
+			name:        util.StringValueOrDefault(name, util.Unknown),
 			deviceId:    deviceId,
 			vendor:      util.StringValueOrDefault(vendor, util.Unknown),
 			versionInfo: versionInfo,
@@ -165,6 +178,14 @@ func registryGraphicsCards() ([]hardware.GraphicsCard, error) {
 			err = fmt.Errorf("registry: failed to get value of ProviderName: %w", err)
 			return nil, err
 		}
+		if matching, _, err := disp.GetStringValue("MatchingDeviceId"); err == nil {
+			if m := matchingDeviceRegex.FindStringSubmatch(matching); m != nil {
+				vendorId, productId := "0x"+strings.ToLower(m[1]), "0x"+strings.ToLower(m[2])
+				deviceId = productId
+				vendor = pciVendorName(vendor, vendorId)
+				name = util.StringValueOrDefault(name, ids.PciDevice(vendorId, productId))
+			}
+		}
 		versionInfo, _, err := disp.GetStringValue("DriverVersion")
 		if errors.Is(err, windows.ERROR_ACCESS_DENIED) {
 			continue
@@ -0,0 +1,78 @@
+/*
+ * Copyright 2016-2024 The OSHI Project Contributors
+ * SPDX-License-Identifier: MIT
+ */
+
+package hardware
+
+import (
+	"errors"
+	"goshi/sysinfo/hardware"
+	"goshi/util"
+)
+
+var errNotImplemented = errors.New("windows: not implemented")
+
+type WindowsHardwareAbstractionLayer struct {
+	computerSystem func() (hardware.ComputerSystem, error)
+	processor      func() (hardware.CentralProcessor, error)
+	memory         func() hardware.GlobalMemory
+	graphicsCards  func() ([]hardware.GraphicsCard, error)
+}
+
+func (w WindowsHardwareAbstractionLayer) ComputerSystem() (hardware.ComputerSystem, error) {
+	return w.computerSystem()
+}
+
+func (w WindowsHardwareAbstractionLayer) Processor() (hardware.CentralProcessor, error) {
+	return w.processor()
+}
+
+func (w WindowsHardwareAbstractionLayer) Memory() (hardware.GlobalMemory, error) {
+	return w.memory(), nil
+}
+
+func (w WindowsHardwareAbstractionLayer) GraphicsCards() ([]hardware.GraphicsCard, error) {
+	return w.graphicsCards()
+}
+
+func (w WindowsHardwareAbstractionLayer) PciDevices() ([]hardware.PciDevice, error) {
+	return nil, errNotImplemented
+}
+
+func (w WindowsHardwareAbstractionLayer) DiskStores() ([]hardware.HWDiskStore, error) {
+	return nil, errNotImplemented
+}
+
+func (w WindowsHardwareAbstractionLayer) NetworkIFs(includeLocal bool) ([]hardware.NetworkIF, error) {
+	return nil, errNotImplemented
+}
+
+func (w WindowsHardwareAbstractionLayer) Sensors() (hardware.Sensors, error) {
+	return nil, errNotImplemented
+}
+
+func (w WindowsHardwareAbstractionLayer) PowerSources() ([]hardware.PowerSource, error) {
+	return nil, errNotImplemented
+}
+
+func (w WindowsHardwareAbstractionLayer) UsbDevices(tree bool) ([]hardware.UsbDevice, error) {
+	return nil, errNotImplemented
+}
+
+func (w WindowsHardwareAbstractionLayer) SoundCards() ([]hardware.SoundCard, error) {
+	return nil, errNotImplemented
+}
+
+func (w WindowsHardwareAbstractionLayer) Displays() ([]hardware.Display, error) {
+	return nil, errNotImplemented
+}
+
+func HardwareAbstractionLayer() hardware.HardwareAbstractionLayer {
+	return WindowsHardwareAbstractionLayer{
+		computerSystem: util.MemoizeWithError(ComputerSystem, -1),
+		processor:      util.MemoizeWithError(Processor, -1),
+		memory:         util.Memoize(GlobalMemory, -1),
+		graphicsCards:  util.MemoizeWithError(GPUs, util.DefaultExpiration()),
+	}
+}
@@ -1,3 +1,5 @@
+//go:build windows
+
 /*
  * Copyright 2016-2024 The OSHI Project Contributors
  * SPDX-License-Identifier: MIT
@@ -6,97 +8,89 @@
 package hardware
 
 import (
-	_ "embed"
-	"encoding/json"
-	"fmt"
 	"goshi/sysinfo/hardware"
 	"goshi/util"
 	"goshi/windows/internal"
-	"strconv"
-)
-
-var (
-	//go:embed memoryTypes.json
-	memoryTypes []byte
-
-	memory map[uint16]string
-	smBios map[uint32]string
 )
 
 type WindowsVirtualMemory struct {
-	global WindowsGlobalMemory
+	perfInfo   func() (internal.PerformanceInformation, error)
+	pagingFile func() ([]internal.Win32PerfRawDataPerfOSPagingFile, error)
+	perfMemory func() ([]internal.Win32PerfRawDataPerfOSMemory, error)
+}
+
+func (w WindowsVirtualMemory) pageSize() int64 {
+	_, _, c := readPerfInfo(w.perfInfo)
+	return c
 }
 
 func (w WindowsVirtualMemory) SwapUsed() int64 {
-	return w.global.PageSize() * querySwapUsed()
+	return w.pageSize() * querySwapUsed(w.pagingFile)
 }
 
 func (w WindowsVirtualMemory) SwapTotal() int64 {
-	a, _, _ := querySwapTotalVirtMaxVirtUsed()
-	return w.global.PageSize() * a
+	a, _, _ := querySwapTotalVirtMaxVirtUsed(w.perfInfo)
+	return w.pageSize() * a
 }
 
 func (w WindowsVirtualMemory) VirtualMax() int64 {
-	_, b, _ := querySwapTotalVirtMaxVirtUsed()
-	return w.global.PageSize() * b
+	_, b, _ := querySwapTotalVirtMaxVirtUsed(w.perfInfo)
+	return w.pageSize() * b
 }
 
 func (w WindowsVirtualMemory) VirtualInUse() int64 {
-	_, _, c := querySwapTotalVirtMaxVirtUsed()
-	return w.global.PageSize() * c
+	_, _, c := querySwapTotalVirtMaxVirtUsed(w.perfInfo)
+	return w.pageSize() * c
 
 }
 
 func (w WindowsVirtualMemory) SwapPagesIn() int64 {
-	a, _ := queryPageSwaps()
+	a, _ := queryPageSwaps(w.perfMemory)
 	return a
 }
 
 func (w WindowsVirtualMemory) SwapPagesOut() int64 {
-	_, b := queryPageSwaps()
+	_, b := queryPageSwaps(w.perfMemory)
 	return b
 }
 
 type WindowsGlobalMemory struct {
+	perfInfo       func() (internal.PerformanceInformation, error)
+	physicalMemory func() ([]internal.Win32PhysicalMemory, error)
+	virtualMemory  hardware.VirtualMemory
 }
 
 func (w WindowsGlobalMemory) Available() int64 {
-	a, _, _ := readPerfInfo()
+	a, _, _ := readPerfInfo(w.perfInfo)
 	return a
 }
 
 func (w WindowsGlobalMemory) Total() int64 {
-	_, b, _ := readPerfInfo()
+	_, b, _ := readPerfInfo(w.perfInfo)
 	return b
 }
 
 func (w WindowsGlobalMemory) PageSize() int64 {
-	_, _, c := readPerfInfo()
+	_, _, c := readPerfInfo(w.perfInfo)
 	return c
 }
 
 func (w WindowsGlobalMemory) VirtualMemory() hardware.VirtualMemory {
-	return WindowsVirtualMemory{
-		global: w,
-	}
+	return w.virtualMemory
 }
 
-func (w WindowsGlobalMemory) PhysicalMemory() []hardware.PhysicalMemory {
-	q, err := internal.WmiQueryPhysicalMemory()
+func (w WindowsGlobalMemory) PhysicalMemory() ([]hardware.PhysicalMemory, error) {
+	q, err := w.physicalMemory()
 	if err != nil {
-		return nil
+		return nil, err
 	}
 	memories := make([]hardware.PhysicalMemory, 0)
 	for _, mems := range q {
 		var memoryType string
-		var exists bool
 		if internal.Windows10OrGreater {
-			memoryType, exists = smBios[mems.SMBiosMemoryType]
+			memoryType = hardware.SMBiosMemoryType(mems.SMBiosMemoryType)
 		} else {
-			memoryType, exists = memory[mems.MemoryType]
-		}
-		if !exists {
-			memoryType = util.Unknown
+			memoryType = hardware.MemoryType(mems.MemoryType)
 		}
 		pmem := hardware.NewPhysicalMemory(
 			mems.BankLabel,
@@ -109,23 +103,11 @@ func (w WindowsGlobalMemory) PhysicalMemory() []hardware.PhysicalMemory {
 		)
 		memories = append(memories, pmem)
 	}
-	return memories
-}
-
-func parseMemoryTypes[T uint16 | uint32](d map[string]string, bits int) (map[T]string, error) {
-	res := make(map[T]string)
-	for k, v := range d {
-		i, err := strconv.ParseUint(k, 10, bits)
-		if err != nil {
-			return nil, err
-		}
-		res[T(i)] = v
-	}
-	return res, nil
+	return memories, nil
 }
 
-func readPerfInfo() (int64, int64, int64) {
-	pi, err := internal.GetPerformanceInfo()
+func readPerfInfo(perfInfo func() (internal.PerformanceInformation, error)) (int64, int64, int64) {
+	pi, err := perfInfo()
 	if err != nil {
 		return 0, 0, 4098
 	}
@@ -135,8 +117,8 @@ func readPerfInfo() (int64, int64, int64) {
 	return memAvailable, memTotal, pageSize
 }
 
-func querySwapTotalVirtMaxVirtUsed() (int64, int64, int64) {
-	pi, err := internal.GetPerformanceInfo()
+func querySwapTotalVirtMaxVirtUsed(perfInfo func() (internal.PerformanceInformation, error)) (int64, int64, int64) {
+	pi, err := perfInfo()
 	if err != nil {
 		return 0, 0, 0
 	}
@@ -146,41 +128,32 @@ func querySwapTotalVirtMaxVirtUsed() (int64, int64, int64) {
 	return a, b, c
 }
 
-func querySwapUsed() int64 {
-	pi, err := internal.WmiQueryPerfRawDataPagingFile()
-	if err != nil {
+func querySwapUsed(pagingFile func() ([]internal.Win32PerfRawDataPerfOSPagingFile, error)) int64 {
+	pi, err := pagingFile()
+	if err != nil || len(pi) == 0 {
 		return 0
 	}
 	return int64(pi[0].PercentUsage)
 }
 
-func queryPageSwaps() (int64, int64) {
-	pi, err := internal.WmiQueryPerfRawDataMemory()
-	if err != nil {
+func queryPageSwaps(perfMemory func() ([]internal.Win32PerfRawDataPerfOSMemory, error)) (int64, int64) {
+	pi, err := perfMemory()
+	if err != nil || len(pi) == 0 {
 		return 0, 0
 	}
 	return int64(pi[0].PagesInputPerSec), int64(pi[0].PagesOutputPerSec)
 }
 
 func GlobalMemory() hardware.GlobalMemory {
-	return WindowsGlobalMemory{}
-}
-
-func init() {
-	var data map[string]map[string]string
-	err := json.Unmarshal(memoryTypes, &data)
-	if err != nil {
-		err = fmt.Errorf("memory: error unmarshalling memory types: %w", err)
-		panic(err)
-	}
-	memory, err = parseMemoryTypes[uint16](data["memory"], 16)
-	if err != nil {
-		err = fmt.Errorf("memory: error parsing memory hardware: %w", err)
-		panic(err)
-	}
-	smBios, err = parseMemoryTypes[uint32](data["smBios"], 32)
-	if err != nil {
-		err = fmt.Errorf("memory: error parsing smBios hardware: %w", err)
-		panic(err)
+	ttl := util.DefaultExpiration()
+	perfInfo := util.MemoizeWithError(internal.GetPerformanceInfo, ttl)
+	return WindowsGlobalMemory{
+		perfInfo:       perfInfo,
+		physicalMemory: util.MemoizeWithError(internal.WmiQueryPhysicalMemory, ttl),
+		virtualMemory: WindowsVirtualMemory{
+			perfInfo:   perfInfo,
+			pagingFile: util.MemoizeWithError(internal.WmiQueryPerfRawDataPagingFile, ttl),
+			perfMemory: util.MemoizeWithError(internal.WmiQueryPerfRawDataMemory, ttl),
+		},
 	}
 }
@@ -1,3 +1,5 @@
+//go:build windows
+
 /*
  * Copyright 2016-2024 The OSHI Project Contributors
  * SPDX-License-Identifier: MIT
@@ -9,8 +11,12 @@ import (
 	"fmt"
 	set "github.com/deckarep/golang-set/v2"
 	"golang.org/x/sys/windows/registry"
+	"goshi/internal/cpuid"
 	"goshi/sysinfo/hardware"
+	"goshi/util"
 	"goshi/windows/internal"
+	"sort"
+	"strconv"
 	"strings"
 )
 
@@ -18,11 +24,50 @@ const (
 	cpuRegistryPath = `HARDWARE\DESCRIPTION\System\CentralProcessor\`
 )
 
+var (
+	// https://learn.microsoft.com/en-us/windows/win32/api/processthreadsapi/nf-processthreadsapi-isprocessorfeaturepresent
+	processorFeatures = map[uint32]string{
+		3:  "mmx",
+		6:  "sse",
+		7:  "3dnow",
+		8:  "tsc",
+		9:  "pae",
+		10: "sse2",
+		12: "nx",
+		13: "sse3",
+		14: "cx16",
+		17: "xsave",
+		19: "neon",
+		22: "fsgsbase",
+		28: "rdrand",
+		30: "aes",
+		31: "crc32",
+		32: "rdtscp",
+		33: "rdpid",
+		34: "atomics",
+		36: "ssse3",
+		37: "sse4_1",
+		38: "sse4_2",
+		39: "avx",
+		40: "avx2",
+		41: "avx512f",
+		42: "erms",
+		43: "asimddp",
+		44: "jscvt",
+		45: "lrcpc",
+		46: "sve",
+	}
+)
+
 type WindowsCentralProcessor struct {
-	processorIdentifier    hardware.ProcessorIdentifier
-	physicalPackageCount   int
-	physicalProcessorCount int
-	logicalProcessorCount  int
+	hardware.CpuTicks
+	processorIdentifier  hardware.ProcessorIdentifier
+	physicalPackageCount int
+	logicalProcessors    []hardware.LogicalProcessor
+	physicalProcessors   []hardware.PhysicalProcessor
+	processorCaches      []hardware.ProcessorCache
+	maxFreq              int64
+	currentFreq          func() []int64
 }
 
 func (w WindowsCentralProcessor) ProcessorIdentifier() hardware.ProcessorIdentifier {
@@ -34,11 +79,49 @@ func (w WindowsCentralProcessor) PhysicalPackageCount() int {
 }
 
 func (w WindowsCentralProcessor) PhysicalProcessorCount() int {
-	return w.physicalProcessorCount
+	return len(w.physicalProcessors)
 }
 
 func (w WindowsCentralProcessor) LogicalProcessorCount() int {
-	return w.logicalProcessorCount
+	return len(w.logicalProcessors)
+}
+
+func (w WindowsCentralProcessor) LogicalProcessors() []hardware.LogicalProcessor {
+	return w.logicalProcessors
+}
+
+func (w WindowsCentralProcessor) PhysicalProcessors() []hardware.PhysicalProcessor {
+	return w.physicalProcessors
+}
+
+func (w WindowsCentralProcessor) CoresByEfficiency() (performance, efficiency []hardware.PhysicalProcessor) {
+	return hardware.CoresByEfficiency(w.physicalProcessors)
+}
+
+func (w WindowsCentralProcessor) ProcessorCaches() []hardware.ProcessorCache {
+	return w.processorCaches
+}
+
+func (w WindowsCentralProcessor) MaxFreq() int64 {
+	return w.maxFreq
+}
+
+func (w WindowsCentralProcessor) CurrentFreq() []int64 {
+	return w.currentFreq()
+}
+
+// FrequencyPolicies is not available on windows, power plans are not reported.
+func (w WindowsCentralProcessor) FrequencyPolicies() []hardware.FrequencyPolicy {
+	return make([]hardware.FrequencyPolicy, 0)
+}
+
+// SystemLoadAverage is not available on windows, every average is negative.
+func (w WindowsCentralProcessor) SystemLoadAverage(nelem int) []float64 {
+	average := make([]float64, min(max(nelem, 1), 3))
+	for i := range average {
+		average[i] = -1
+	}
+	return average
 }
 
 func processorCounts() (internal.LogicalProcessorInformation, error) {
@@ -59,6 +142,16 @@ func parseIdentifier(id, key string) string {
 	return ""
 }
 
+func processorFeatureNames() []string {
+	features := make([]string, 0)
+	for feature, name := range processorFeatures {
+		if internal.IsProcessorFeaturePresent(feature) {
+			features = append(features, name)
+		}
+	}
+	return features
+}
+
 func processorIdentifier() (hardware.ProcessorIdentifier, error) {
 	var err error
 	acc := uint32(registry.QUERY_VALUE | registry.ENUMERATE_SUB_KEYS)
@@ -118,12 +211,84 @@ func processorIdentifier() (hardware.ProcessorIdentifier, error) {
 		model = parseIdentifier(identifier, "Model")
 		stepping = parseIdentifier(identifier, "Stepping")
 	}
+	features := processorFeatureNames()
+	if info, ok := cpuid.Read(); ok {
+		vendor = util.StringValueOrDefault(info.Vendor, vendor)
+		name = util.StringValueOrDefault(info.Brand, name)
+		family, model, stepping = strconv.Itoa(info.Family), strconv.Itoa(info.Model), strconv.Itoa(info.Stepping)
+		processorID = info.ProcessorID
+		if info.Hypervisor {
+			features = append(features, "hypervisor")
+		}
+	}
 	procId := hardware.NewProcessorIdentifier(
-		vendor, name, family, model, stepping, processorID, internal.Is64bit(), freq,
+		vendor, name, family, model, stepping, processorID, internal.Is64bit(), freq, features,
 	)
 	return procId, nil
 }
 
+// processorCpuLoadTicks converts the processor times from 100ns units to milliseconds
+func processorCpuLoadTicks() ([][]int64, error) {
+	perf, err := internal.GetProcessorPerformanceInformation()
+	if err != nil {
+		return nil, err
+	}
+	ticks := make([][]int64, len(perf))
+	for i, p := range perf {
+		t := make([]int64, hardware.TickTypeCount)
+		t[hardware.TickUser] = p.UserTime / 10_000
+		t[hardware.TickSystem] = max(p.KernelTime-p.IdleTime-p.DpcTime-p.InterruptTime, 0) / 10_000
+		t[hardware.TickIdle] = p.IdleTime / 10_000
+		t[hardware.TickIRQ] = p.InterruptTime / 10_000
+		t[hardware.TickSoftIRQ] = p.DpcTime / 10_000
+		ticks[i] = t
+	}
+	return ticks, nil
+}
+
+func systemCpuLoadTicks() ([]int64, error) {
+	perProcessor, err := processorCpuLoadTicks()
+	if err != nil {
+		return nil, err
+	}
+	ticks := make([]int64, hardware.TickTypeCount)
+	for _, procTicks := range perProcessor {
+		for i, t := range procTicks {
+			ticks[i] += t
+		}
+	}
+	return ticks, nil
+}
+
+func maxFreq(count int, procId hardware.ProcessorIdentifier) int64 {
+	maxFreq := int64(-1)
+	info, err := internal.GetProcessorPowerInformation(count)
+	if err == nil {
+		for _, p := range info {
+			maxFreq = max(maxFreq, int64(p.MaxMhz)*1_000_000)
+		}
+	}
+	if maxFreq <= 0 && procId.Frequency() > 0 {
+		return procId.Frequency()
+	}
+	return maxFreq
+}
+
+func currentFreq(count int) []int64 {
+	freqs := make([]int64, count)
+	for i := range freqs {
+		freqs[i] = -1
+	}
+	info, err := internal.GetProcessorPowerInformation(count)
+	if err != nil {
+		return freqs
+	}
+	for i, p := range info {
+		freqs[i] = int64(p.CurrentMhz) * 1_000_000
+	}
+	return freqs
+}
+
 func Processor() (hardware.CentralProcessor, error) {
 	procId, err := processorIdentifier()
 	if err != nil {
@@ -141,6 +306,7 @@ func Processor() (hardware.CentralProcessor, error) {
 			keys.Add(key)
 		}
 		pkgCoreKeys := keys.ToSlice()
+		sort.Ints(pkgCoreKeys)
 		for _, key := range pkgCoreKeys {
 			phyProcs = append(phyProcs, internal.PhysicalProcessor{
 				PhysicalPackageNumber:   key >> 16,
@@ -151,14 +317,53 @@ func Processor() (hardware.CentralProcessor, error) {
 		phyProcs = counts.PhysicalProcessors
 	}
 	physPkgs := set.NewSet[int]()
+	logicalProcessors := make([]hardware.LogicalProcessor, 0, len(counts.LogicalProcessors))
 	for _, logProc := range counts.LogicalProcessors {
 		physPkgs.Add(logProc.PhysicalPackageNumber)
+		logicalProcessors = append(logicalProcessors, hardware.NewLogicalProcessor(
+			logProc.ProcessorNumber,
+			logProc.PhysicalProcessorNumber,
+			logProc.PhysicalPackageNumber,
+			int(logProc.NumaNode),
+			int(logProc.ProcessorGroup),
+		))
 	}
+	physicalProcessors := make([]hardware.PhysicalProcessor, 0, len(phyProcs))
+	for _, phyProc := range phyProcs {
+		physicalProcessors = append(physicalProcessors, hardware.NewPhysicalProcessor(
+			phyProc.PhysicalPackageNumber,
+			phyProc.PhysicalProcessorNumber,
+			int(phyProc.Efficiency),
+			phyProc.IdString,
+		))
+	}
+	processorCaches := make([]hardware.ProcessorCache, 0, len(counts.Caches))
+	for _, cache := range counts.Caches {
+		processorCaches = append(processorCaches, hardware.NewProcessorCache(
+			int(cache.Level),
+			int(cache.Associativity),
+			int(cache.LineSize),
+			int64(cache.CacheSize),
+			hardware.CacheType(cache.Type),
+		))
+	}
+	sort.SliceStable(processorCaches, func(i, j int) bool {
+		if processorCaches[i].Level() != processorCaches[j].Level() {
+			return processorCaches[i].Level() < processorCaches[j].Level()
+		}
+		return processorCaches[i].Type() > processorCaches[j].Type()
+	})
 	proc := WindowsCentralProcessor{
-		processorIdentifier:    procId,
-		physicalProcessorCount: len(counts.PhysicalProcessors),
-		physicalPackageCount:   physPkgs.Cardinality(),
-		logicalProcessorCount:  len(counts.LogicalProcessors),
+		CpuTicks:             hardware.NewCpuTicks(systemCpuLoadTicks, processorCpuLoadTicks, len(logicalProcessors)),
+		processorIdentifier:  procId,
+		physicalPackageCount: physPkgs.Cardinality(),
+		logicalProcessors:    logicalProcessors,
+		physicalProcessors:   physicalProcessors,
+		processorCaches:      processorCaches,
+		maxFreq:              maxFreq(len(logicalProcessors), procId),
+		currentFreq: util.Memoize(func() []int64 {
+			return currentFreq(len(logicalProcessors))
+		}, util.DefaultExpiration()),
 	}
 	return proc, nil
 }
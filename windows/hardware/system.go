@@ -0,0 +1,101 @@
+//go:build windows
+
+/*
+ * Copyright 2016-2024 The OSHI Project Contributors
+ * SPDX-License-Identifier: MIT
+ */
+
+package hardware
+
+import (
+	"fmt"
+	"goshi/sysinfo/hardware"
+	"goshi/util"
+	"goshi/util/smbios"
+	"goshi/windows/internal"
+)
+
+type WindowsComputerSystem struct {
+	manufacturer, model, serialNumber, hardwareUUID string
+	firmware                                        hardware.Firmware
+	baseboard                                       hardware.Baseboard
+}
+
+func (w WindowsComputerSystem) Manufacturer() string {
+	return w.manufacturer
+}
+
+func (w WindowsComputerSystem) Model() string {
+	return w.model
+}
+
+func (w WindowsComputerSystem) SerialNumber() string {
+	return w.serialNumber
+}
+
+func (w WindowsComputerSystem) HardwareUUID() string {
+	return w.hardwareUUID
+}
+
+func (w WindowsComputerSystem) Firmware() hardware.Firmware {
+	return w.firmware
+}
+
+func (w WindowsComputerSystem) Baseboard() hardware.Baseboard {
+	return w.baseboard
+}
+
+func ComputerSystem() (hardware.ComputerSystem, error) {
+	raw, err := internal.GetRawSMBIOSData()
+	if err != nil {
+		return nil, err
+	}
+	table, err := smbios.ParseRawSMBIOSData(raw)
+	if err != nil {
+		return nil, err
+	}
+	system := WindowsComputerSystem{
+		manufacturer: util.Unknown,
+		model:        util.Unknown,
+		serialNumber: util.Unknown,
+		hardwareUUID: util.Unknown,
+		firmware:     hardware.NewFirmware(util.Unknown, "BIOS", util.Unknown, util.Unknown, util.Unknown),
+		baseboard:    hardware.NewBaseboard(util.Unknown, util.Unknown, util.Unknown, util.Unknown),
+	}
+	if bios := table.BIOS(); len(bios) > 0 {
+		name := "BIOS"
+		if bios[0].UEFI() {
+			name = "UEFI"
+		}
+		description := util.Unknown
+		if bios[0].MajorRelease != 0xFF {
+			description = fmt.Sprintf("BIOS Revision: %d.%d", bios[0].MajorRelease, bios[0].MinorRelease)
+		}
+		system.firmware = hardware.NewFirmware(
+			util.StringValueOrDefault(bios[0].Vendor, util.Unknown),
+			name,
+			description,
+			util.StringValueOrDefault(bios[0].Version, util.Unknown),
+			util.StringValueOrDefault(smbios.FormatDate(bios[0].ReleaseDate), util.Unknown),
+		)
+	}
+	if boards := table.Baseboard(); len(boards) > 0 {
+		system.baseboard = hardware.NewBaseboard(
+			util.StringValueOrDefault(boards[0].Manufacturer, util.Unknown),
+			util.StringValueOrDefault(boards[0].Product, util.Unknown),
+			util.StringValueOrDefault(boards[0].Version, util.Unknown),
+			util.StringValueOrDefault(boards[0].SerialNumber, util.Unknown),
+		)
+	}
+	if systems := table.System(); len(systems) > 0 {
+		model := systems[0].ProductName
+		if len(systems[0].Version) != 0 && len(model) != 0 {
+			model = fmt.Sprintf("%s (version: %s)", model, systems[0].Version)
+		}
+		system.manufacturer = util.StringValueOrDefault(systems[0].Manufacturer, util.Unknown)
+		system.model = util.StringValueOrDefault(model, util.Unknown)
+		system.serialNumber = util.StringValueOrDefault(systems[0].SerialNumber, system.baseboard.SerialNumber())
+		system.hardwareUUID = util.StringValueOrDefault(systems[0].UUID, util.Unknown)
+	}
+	return system, nil
+}
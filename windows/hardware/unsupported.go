@@ -0,0 +1,31 @@
+//go:build !windows
+
+/*
+ * Copyright 2016-2024 The OSHI Project Contributors
+ * SPDX-License-Identifier: MIT
+ */
+
+package hardware
+
+import (
+	"errors"
+	"goshi/sysinfo/hardware"
+)
+
+var errUnsupported = errors.New("windows: unsupported os")
+
+func ComputerSystem() (hardware.ComputerSystem, error) {
+	return nil, errUnsupported
+}
+
+func Processor() (hardware.CentralProcessor, error) {
+	return nil, errUnsupported
+}
+
+func GlobalMemory() hardware.GlobalMemory {
+	return nil
+}
+
+func GPUs() ([]hardware.GraphicsCard, error) {
+	return nil, errUnsupported
+}
@@ -1,3 +1,5 @@
+//go:build windows
+
 /*
  * Copyright 2016-2024 The OSHI Project Contributors
  * SPDX-License-Identifier: MIT
@@ -35,6 +37,15 @@ func (p ProcessorCore) GetProcessorMask() uintptr {
 	return p.ProcessorMask
 }
 
+type CacheDescriptor struct {
+	ProcessorMask uintptr
+	ProcessorCache
+}
+
+func (c CacheDescriptor) GetProcessorMask() uintptr {
+	return c.ProcessorMask
+}
+
 func getBitMatchingPackageNumber(maskList []int64, logProc int32) int {
 	for i, mask := range maskList {
 		if mask&(1<<logProc) != 0 {
@@ -58,13 +69,15 @@ func getSystemLogicalProcessorInformation() ([]SystemLogicalProcessorInformation
 	type lpi struct {
 		pm uintptr
 		r  uint32
-		pi [16]byte
+		// the union is aligned like the ULONGLONG it contains
+		pi [2]uint64
 	}
 	arr := make([]SystemLogicalProcessorInformation, 0)
 	s := int(unsafe.Sizeof(lpi{}))
 	for off := 0; off+s <= int(lpiRl); off += s {
 		ptr := unsafe.Pointer(&buf[off])
 		str := (*lpi)(ptr)
+		pi := (*[16]byte)(unsafe.Pointer(&str.pi))
 		switch str.r {
 		case RelationProcessorPackage:
 			fallthrough
@@ -72,7 +85,12 @@ func getSystemLogicalProcessorInformation() ([]SystemLogicalProcessorInformation
 			arr = append(arr, ProcessorCore{
 				ProcessorMask: str.pm,
 				Relationship:  str.r,
-				Flags:         str.pi[0],
+				Flags:         pi[0],
+			})
+		case RelationCache:
+			arr = append(arr, CacheDescriptor{
+				ProcessorMask:  str.pm,
+				ProcessorCache: *(*ProcessorCache)(unsafe.Pointer(pi)),
 			})
 		default:
 		}
@@ -83,13 +101,18 @@ func getSystemLogicalProcessorInformation() ([]SystemLogicalProcessorInformation
 func GetLogicalProcessorInformation() (LogicalProcessorInformation, error) {
 	packageMaskList := make([]int64, 0)
 	coreMaskList := make([]int64, 0)
+	caches := make([]ProcessorCache, 0)
 	procInfo, err := getSystemLogicalProcessorInformation()
 	if err != nil {
 		return LogicalProcessorInformation{}, err
 	}
 	for _, info := range procInfo {
 		mask := int64(info.GetProcessorMask())
-		switch info.GetProcessorMask() {
+		if cache, ok := info.(CacheDescriptor); ok {
+			caches = append(caches, cache.ProcessorCache)
+			continue
+		}
+		switch info.(ProcessorCore).Relationship {
 		case RelationProcessorPackage:
 			packageMaskList = append(packageMaskList, mask)
 		case RelationProcessorCore:
@@ -117,6 +140,7 @@ func GetLogicalProcessorInformation() (LogicalProcessorInformation, error) {
 	res := LogicalProcessorInformation{
 		logProcs,
 		nil,
+		caches,
 	}
 	return res, nil
 }
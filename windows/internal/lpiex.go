@@ -1,3 +1,5 @@
+//go:build windows
+
 /*
  * Copyright 2016-2024 The OSHI Project Contributors
  * SPDX-License-Identifier: MIT
@@ -16,6 +18,7 @@ import (
 const (
 	RelationProcessorCore = iota
 	RelationNumaNode
+	RelationCache
 	RelationProcessorPackage
 	RelationAll = 0xFFFF
 )
@@ -35,6 +38,14 @@ type SystemLogicalProcessorInformationEx interface {
 type LogicalProcessorInformation struct {
 	LogicalProcessors  []LogicalProcessor
 	PhysicalProcessors []PhysicalProcessor
+	Caches             []ProcessorCache
+}
+
+type ProcessorCache struct {
+	Level, Associativity uint8
+	LineSize             uint16
+	CacheSize            uint32
+	Type                 uint32
 }
 
 type LogicalProcessor struct {
@@ -76,6 +87,15 @@ func (n NumaNodeRelationship) GetRelationship() uint32 {
 	return n.Relationship
 }
 
+type CacheRelationship struct {
+	Relationship uint32
+	ProcessorCache
+}
+
+func (c CacheRelationship) GetRelationship() uint32 {
+	return c.Relationship
+}
+
 type GroupAffinity struct {
 	Mask  uintptr
 	Group uint16
@@ -151,6 +171,12 @@ func parseNumaRelationship(uPtr unsafe.Pointer) NumaNodeRelationship {
 	}
 }
 
+func parseCacheRelationship(uPtr unsafe.Pointer) CacheRelationship {
+	return CacheRelationship{
+		ProcessorCache: *(*ProcessorCache)(uPtr),
+	}
+}
+
 func GetSystemLogicalProcessorInformationEx() ([]SystemLogicalProcessorInformationEx, error) {
 	_, _, err := lpiEx.Call(uintptr(RelationAll), 0, uintptr(unsafe.Pointer(&lpiExRl)))
 	if !errors.Is(err, windows.ERROR_INSUFFICIENT_BUFFER) {
@@ -183,6 +209,10 @@ func GetSystemLogicalProcessorInformationEx() ([]SystemLogicalProcessorInformati
 			rel := parseNumaRelationship(headerPtr)
 			rel.Relationship = info.rel
 			relationships = append(relationships, rel)
+		case RelationCache:
+			rel := parseCacheRelationship(headerPtr)
+			rel.Relationship = info.rel
+			relationships = append(relationships, rel)
 		default:
 
 		}
@@ -199,6 +229,7 @@ func GetLogicalProcessorInformationEx() (LogicalProcessorInformation, error) {
 	packages := make([][]GroupAffinity, 0)
 	cores := make([]GroupAffinity, 0)
 	numaNodes := make([]NumaNodeRelationship, 0)
+	caches := make([]ProcessorCache, 0)
 	coreEfficiencyMap := make(map[GroupAffinity]uint8)
 	for _, info := range procInfo {
 		switch info.GetRelationship() {
@@ -213,6 +244,8 @@ func GetLogicalProcessorInformationEx() (LogicalProcessorInformation, error) {
 			numaNodes = append(numaNodes, numa)
 		case RelationProcessorPackage:
 			packages = append(packages, info.(ProcessorRelationship).GroupMasks)
+		case RelationCache:
+			caches = append(caches, info.(CacheRelationship).ProcessorCache)
 		default:
 		}
 	}
@@ -237,7 +270,7 @@ func GetLogicalProcessorInformationEx() (LogicalProcessorInformation, error) {
 		lowBit := int32(bits.TrailingZeros(mask))
 		hiBit := 63 - int32(bits.LeadingZeros(mask))
 		for lp := lowBit; lp <= hiBit; lp++ {
-			if (mask & 1 << lp) == 0 {
+			if mask&(1<<lp) == 0 {
 				continue
 			}
 			coreId := matchingCore(cores, group, lp)
@@ -258,6 +291,7 @@ func GetLogicalProcessorInformationEx() (LogicalProcessorInformation, error) {
 	res := LogicalProcessorInformation{
 		logProcs,
 		physicalProcs,
+		caches,
 	}
 	return res, nil
 }
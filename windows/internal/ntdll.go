@@ -0,0 +1,49 @@
+//go:build windows
+
+/*
+ * Copyright 2016-2024 The OSHI Project Contributors
+ * SPDX-License-Identifier: MIT
+ */
+
+package internal
+
+import (
+	"errors"
+	"fmt"
+	"golang.org/x/sys/windows"
+	"unsafe"
+)
+
+// SystemProcessorPerformanceInformation holds the times of a logical processor, in 100ns units.
+// KernelTime includes IdleTime, DpcTime and InterruptTime.
+type SystemProcessorPerformanceInformation struct {
+	IdleTime       int64
+	KernelTime     int64
+	UserTime       int64
+	DpcTime        int64
+	InterruptTime  int64
+	InterruptCount uint32
+}
+
+// GetProcessorPerformanceInformation returns the times of every logical processor in the processor group of the calling thread.
+func GetProcessorPerformanceInformation() ([]SystemProcessorPerformanceInformation, error) {
+	size := int(unsafe.Sizeof(SystemProcessorPerformanceInformation{}))
+	buf := make([]SystemProcessorPerformanceInformation, 64)
+	var rl uint32
+	for {
+		err := windows.NtQuerySystemInformation(
+			windows.SystemProcessorPerformanceInformation,
+			unsafe.Pointer(&buf[0]),
+			uint32(len(buf)*size),
+			&rl,
+		)
+		if errors.Is(err, windows.STATUS_INFO_LENGTH_MISMATCH) {
+			buf = make([]SystemProcessorPerformanceInformation, len(buf)*2)
+			continue
+		} else if err != nil {
+			err = fmt.Errorf("ntdll: failed to query processor performance information: %w", err)
+			return nil, err
+		}
+		return buf[:int(rl)/size], nil
+	}
+}
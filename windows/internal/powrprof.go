@@ -0,0 +1,48 @@
+//go:build windows
+
+/*
+ * Copyright 2016-2024 The OSHI Project Contributors
+ * SPDX-License-Identifier: MIT
+ */
+
+package internal
+
+import (
+	"fmt"
+	"golang.org/x/sys/windows"
+	"unsafe"
+)
+
+const (
+	// https://learn.microsoft.com/en-us/windows/win32/api/powerbase/nf-powerbase-callntpowerinformation
+	processorInformation = 11
+)
+
+var (
+	powrprof           = windows.NewLazySystemDLL("powrprof.dll")
+	ntPowerInformation = powrprof.NewProc("CallNtPowerInformation")
+)
+
+type ProcessorPowerInformation struct {
+	Number, MaxMhz, CurrentMhz, MhzLimit, MaxIdleState, CurrentIdleState uint32
+}
+
+// GetProcessorPowerInformation returns the frequencies of the first count logical processors.
+func GetProcessorPowerInformation(count int) ([]ProcessorPowerInformation, error) {
+	if count < 1 {
+		return nil, nil
+	}
+	buf := make([]ProcessorPowerInformation, count)
+	size := uintptr(count) * unsafe.Sizeof(ProcessorPowerInformation{})
+	res, _, _ := ntPowerInformation.Call(
+		processorInformation,
+		0,
+		0,
+		uintptr(unsafe.Pointer(&buf[0])),
+		size,
+	)
+	if res != 0 {
+		return nil, fmt.Errorf("powrprof: failed to get processor power information: %w", windows.NTStatus(res))
+	}
+	return buf, nil
+}
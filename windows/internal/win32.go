@@ -1,3 +1,5 @@
+//go:build windows
+
 /*
  * Copyright 2016-2024 The OSHI Project Contributors
  * SPDX-License-Identifier: MIT
@@ -17,6 +19,9 @@ const (
 	ia64  uint16 = 6
 	amd64 uint16 = 9
 	arm64 uint16 = 12
+
+	// 'RSMB', the provider of the raw SMBIOS table
+	firmwareProviderRSMB = 0x52534D42
 )
 
 var (
@@ -24,6 +29,8 @@ var (
 	psapi              = windows.NewLazySystemDLL("Psapi.dll")
 	nativeSystemInfo   = kernel32.NewProc("GetNativeSystemInfo")
 	perfInfo           = psapi.NewProc("GetPerformanceInfo")
+	processorFeature   = kernel32.NewProc("IsProcessorFeaturePresent")
+	firmwareTable      = kernel32.NewProc("GetSystemFirmwareTable")
 	Windows7OrGreater  bool
 	VistaOrGreater     bool
 	Windows10OrGreater bool
@@ -61,6 +68,26 @@ func Is64bit() bool {
 	return sysInfo.arch == amd64 || sysInfo.arch == arm64 || sysInfo.arch == ia64
 }
 
+// IsProcessorFeaturePresent reports whether one of the PF_* features is present.
+func IsProcessorFeaturePresent(feature uint32) bool {
+	res, _, _ := processorFeature.Call(uintptr(feature))
+	return res != 0
+}
+
+// GetRawSMBIOSData returns the RawSMBIOSData structure of the RSMB firmware table provider.
+func GetRawSMBIOSData() ([]byte, error) {
+	size, _, err := firmwareTable.Call(firmwareProviderRSMB, 0, 0, 0)
+	if size == 0 {
+		return nil, fmt.Errorf("smbios: failed to get firmware table size: %w", err)
+	}
+	buf := make([]byte, size)
+	res, _, err := firmwareTable.Call(firmwareProviderRSMB, 0, uintptr(unsafe.Pointer(&buf[0])), size)
+	if res == 0 || res > size {
+		return nil, fmt.Errorf("smbios: failed to get firmware table: %w", err)
+	}
+	return buf[:res], nil
+}
+
 func GetPerformanceInfo() (PerformanceInformation, error) {
 	pi := PerformanceInformation{}
 	cb := unsafe.Sizeof(pi)
@@ -0,0 +1,107 @@
+//go:build windows
+
+/*
+ * Copyright 2016-2024 The OSHI Project Contributors
+ * SPDX-License-Identifier: MIT
+ */
+
+package software
+
+import (
+	"golang.org/x/sys/windows"
+	"golang.org/x/sys/windows/registry"
+	"goshi/sysinfo/software"
+	"goshi/windows/internal"
+	"strconv"
+)
+
+const (
+	currentVersionRegistryPath = `SOFTWARE\Microsoft\Windows NT\CurrentVersion`
+	verNtWorkstation           = 1
+)
+
+type WindowsOperatingSystem struct {
+	versionInfo software.OSVersionInfo
+	bitness     int
+}
+
+func (w WindowsOperatingSystem) Family() string {
+	return "Windows"
+}
+
+func (w WindowsOperatingSystem) Manufacturer() string {
+	return "Microsoft"
+}
+
+func (w WindowsOperatingSystem) VersionInfo() software.OSVersionInfo {
+	return w.versionInfo
+}
+
+func (w WindowsOperatingSystem) Bitness() int {
+	return w.bitness
+}
+
+func parseVersion(ver *windows.OsVersionInfoEx) string {
+	build := ver.BuildNumber
+	if ver.ProductType != verNtWorkstation {
+		switch {
+		case ver.MajorVersion < 10:
+			return "Server"
+		case build >= 26100:
+			return "Server 2025"
+		case build >= 20348:
+			return "Server 2022"
+		case build >= 17763:
+			return "Server 2019"
+		default:
+			return "Server 2016"
+		}
+	}
+	switch {
+	case ver.MajorVersion == 10 && build >= 22000:
+		return "11"
+	case ver.MajorVersion == 10:
+		return "10"
+	case ver.MajorVersion == 6 && ver.MinorVersion == 3:
+		return "8.1"
+	case ver.MajorVersion == 6 && ver.MinorVersion == 2:
+		return "8"
+	case ver.MajorVersion == 6 && ver.MinorVersion == 1:
+		return "7"
+	case ver.MajorVersion == 6:
+		return "Vista"
+	default:
+		return strconv.Itoa(int(ver.MajorVersion))
+	}
+}
+
+func queryCodeName() string {
+	key, err := registry.OpenKey(registry.LOCAL_MACHINE, currentVersionRegistryPath, registry.QUERY_VALUE)
+	if err != nil {
+		return ""
+	}
+	defer key.Close()
+	if val, _, err := key.GetStringValue("DisplayVersion"); err == nil {
+		return val
+	}
+	if val, _, err := key.GetStringValue("ReleaseId"); err == nil {
+		return val
+	}
+	return ""
+}
+
+func OperatingSystem() software.OperatingSystem {
+	ver := windows.RtlGetVersion()
+	bitness := 32
+	if internal.Is64bit() {
+		bitness = 64
+	}
+	return WindowsOperatingSystem{
+		versionInfo: software.NewOSVersionInfo(
+			parseVersion(ver),
+			queryCodeName(),
+			strconv.Itoa(int(ver.BuildNumber)),
+		),
+		bitness: bitness,
+	}
+}
@@ -0,0 +1,16 @@
+//go:build !windows
+
+/*
+ * Copyright 2016-2024 The OSHI Project Contributors
+ * SPDX-License-Identifier: MIT
+ */
+
+package software
+
+import (
+	"goshi/sysinfo/software"
+)
+
+func OperatingSystem() software.OperatingSystem {
+	return nil
+}